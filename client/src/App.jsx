@@ -8,6 +8,8 @@ import Login from './pages/Login.jsx'
 import NotFound from './pages/NotFound.jsx'
 import Profile from './pages/Profile.jsx'
 import Register from './pages/Register.jsx'
+import ResetPassword from './pages/ResetPassword.jsx'
+import VerifyEmail from './pages/VerifyEmail.jsx'
 import Wallet from './pages/Wallet.jsx'
 import WalletOverlay from './pages/WalletOverlay.jsx'
 import Cookies from './pages/legal/Cookies.jsx'
@@ -30,6 +32,8 @@ function App() {
           <Route path="/" element={<Home />} />
           <Route path="/register" element={<Register />} />
           <Route path="/login" element={<Login />} />
+          <Route path="/reset-password" element={<ResetPassword />} />
+          <Route path="/verify-email" element={<VerifyEmail />} />
           <Route path="/profile/:username" element={<Profile />} />
           <Route path="/profile/:username/donate" element={<Donate />} />
           <Route path="/legal/terms" element={<Terms />} />
@@ -43,14 +47,7 @@ function App() {
               </ProtectedRoute>
             }
           />
-          <Route
-            path="/wallet/overlay"
-            element={
-              <ProtectedRoute>
-                <WalletOverlay />
-              </ProtectedRoute>
-            }
-          />
+          <Route path="/wallet/overlay" element={<WalletOverlay />} />
           <Route
             path="/dashboard"
             element={
@@ -15,6 +15,46 @@ export const fetchCurrentUser = async () => {
   return data
 }
 
+export const logoutUser = async () => {
+  const { data } = await api.post('/api/auth/logout')
+  return data
+}
+
+export const requestPasswordReset = async (email) => {
+  const { data } = await api.post('/api/auth/password/forgot', { email })
+  return data
+}
+
+export const resetPassword = async (payload) => {
+  const { data } = await api.post('/api/auth/password/reset', payload)
+  return data
+}
+
+export const verifyEmail = async (token) => {
+  const { data } = await api.post('/api/auth/email/verify', { token })
+  return data
+}
+
+export const resendVerificationEmail = async () => {
+  const { data } = await api.post('/api/auth/email/resend')
+  return data
+}
+
+export const fetchSessions = async () => {
+  const { data } = await api.get('/api/auth/sessions')
+  return data
+}
+
+export const revokeSession = async (sessionId) => {
+  const { data } = await api.delete(`/api/auth/sessions/${sessionId}`)
+  return data
+}
+
+export const revokeOtherSessions = async () => {
+  const { data } = await api.post('/api/auth/sessions/revoke-others')
+  return data
+}
+
 export const fetchProfileByUsername = async (username) => {
   const { data } = await api.get(`/api/users/${username}`)
   return data
@@ -30,13 +70,20 @@ export const fetchWalletSummary = async () => {
   return data
 }
 
-export const createDonation = async (payload) => {
-  const { data } = await api.post('/api/donations', payload)
+export const createDonation = async (payload, { idempotencyKey } = {}) => {
+  const headers = idempotencyKey ? { 'Idempotency-Key': idempotencyKey } : undefined
+  const { data } = await api.post('/api/donations', payload, { headers })
   return data
 }
 
-export const fetchSelectedDonations = async (ids) => {
+export const fetchSelectedDonations = async (ids, { overlayKey } = {}) => {
   const query = Array.isArray(ids) ? ids.join(',') : ids
+  if (overlayKey) {
+    const { data } = await api.get(`/api/overlay/donations`, {
+      params: { ids: query, key: overlayKey },
+    })
+    return data
+  }
   const { data } = await api.get(`/api/donations/selected`, {
     params: { ids: query },
   })
@@ -1,16 +1,72 @@
 import axios from 'axios'
 
+export const TOKEN_KEY = 'donation_token'
+export const REFRESH_TOKEN_KEY = 'donation_refresh_token'
+
+const baseURL = import.meta.env.VITE_API_URL ?? 'http://localhost:8080'
+
 const apiClient = axios.create({
-  baseURL: import.meta.env.VITE_API_URL ?? 'http://localhost:8080',
+  baseURL,
   withCredentials: false,
 })
 
+export const storeTokens = ({ token, refreshToken }) => {
+  window.localStorage.setItem(TOKEN_KEY, token)
+  if (refreshToken) {
+    window.localStorage.setItem(REFRESH_TOKEN_KEY, refreshToken)
+  }
+}
+
+export const clearTokens = () => {
+  window.localStorage.removeItem(TOKEN_KEY)
+  window.localStorage.removeItem(REFRESH_TOKEN_KEY)
+}
+
 apiClient.interceptors.request.use((config) => {
-  const token = window.localStorage.getItem('donation_token')
+  const token = window.localStorage.getItem(TOKEN_KEY)
   if (token) {
     config.headers.Authorization = `Bearer ${token}`
   }
   return config
 })
 
+const credentialPaths = ['/api/auth/login', '/api/auth/register', '/api/auth/refresh']
+const isCredentialRequest = (url = '') => credentialPaths.some((path) => url.startsWith(path))
+
+// Yenileme belirteçleri tek kullanımlık olduğundan eşzamanlı 401'ler tek bir yenilemeyi bekler.
+let refreshPromise = null
+
+const refreshTokens = async () => {
+  const refreshToken = window.localStorage.getItem(REFRESH_TOKEN_KEY)
+  if (!refreshToken) {
+    throw new Error('no refresh token')
+  }
+  const { data } = await axios.post(`${baseURL}/api/auth/refresh`, { refreshToken })
+  storeTokens(data)
+  return data.token
+}
+
+apiClient.interceptors.response.use(
+  (response) => response,
+  async (error) => {
+    const original = error.config
+    if (error.response?.status !== 401 || !original || original._retried || isCredentialRequest(original.url)) {
+      return Promise.reject(error)
+    }
+
+    try {
+      refreshPromise = refreshPromise ?? refreshTokens().finally(() => {
+        refreshPromise = null
+      })
+      const token = await refreshPromise
+      original._retried = true
+      original.headers.Authorization = `Bearer ${token}`
+      return apiClient(original)
+    } catch (refreshError) {
+      clearTokens()
+      return Promise.reject(error)
+    }
+  },
+)
+
 export default apiClient
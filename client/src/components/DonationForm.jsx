@@ -3,6 +3,7 @@ import { ArrowRight, CheckCircle2, CreditCard, Info } from 'lucide-react'
 import toast from 'react-hot-toast'
 import { createDonation } from '../api/auth'
 import useAuth from '../hooks/useAuth'
+import { formatCurrency } from '../utils/format'
 
 const TEST_CARD = {
   number: '4242 4242 4242 4242',
@@ -11,8 +12,6 @@ const TEST_CARD = {
   cvc: '123',
 }
 
-const formatCurrency = (value) =>
-  new Intl.NumberFormat('tr-TR', { style: 'currency', currency: 'TRY' }).format(value)
 
 const DonationForm = ({ recipientUsername }) => {
   const [amount, setAmount] = useState('')
@@ -22,6 +21,7 @@ const DonationForm = ({ recipientUsername }) => {
   const [cardCvc, setCardCvc] = useState('')
   const [loading, setLoading] = useState(false)
   const [receipt, setReceipt] = useState(null)
+  const [idempotencyKey, setIdempotencyKey] = useState(() => crypto.randomUUID())
   const { isAuthenticated } = useAuth()
 
   const handleFillTestCard = () => {
@@ -62,10 +62,20 @@ const DonationForm = ({ recipientUsername }) => {
     setLoading(true)
     try {
       const amountValue = Number(amount)
-      const response = await createDonation({
-        amount: amountValue,
-        toUsername: recipientUsername,
-      })
+      const response = await createDonation(
+        {
+          amount: amountValue,
+          toUsername: recipientUsername,
+        },
+        { idempotencyKey },
+      )
+      setIdempotencyKey(crypto.randomUUID())
+
+      const checkoutUrl = response?.checkout?.redirectUrl
+      if (checkoutUrl) {
+        window.location.assign(checkoutUrl)
+        return
+      }
 
       const donation = response?.donation
       const recipient = response?.recipient
@@ -128,7 +138,10 @@ const DonationForm = ({ recipientUsername }) => {
             min="1"
             step="1"
             value={amount}
-            onChange={(event) => setAmount(event.target.value)}
+            onChange={(event) => {
+              setAmount(event.target.value)
+              setIdempotencyKey(crypto.randomUUID())
+            }}
             className="w-full bg-transparent text-center text-5xl font-semibold leading-none outline-none focus:outline-none"
             placeholder="250"
             autoFocus
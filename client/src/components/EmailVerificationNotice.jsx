@@ -0,0 +1,42 @@
+import { useState } from 'react'
+import { MailWarning } from 'lucide-react'
+import toast from 'react-hot-toast'
+import { resendVerificationEmail } from '../api/auth'
+
+const EmailVerificationNotice = ({ email }) => {
+  const [sending, setSending] = useState(false)
+
+  const handleResend = async () => {
+    setSending(true)
+    try {
+      await resendVerificationEmail()
+      toast.success('Doğrulama bağlantısı gönderildi')
+    } catch (error) {
+      toast.error(error.response?.data?.message ?? 'Doğrulama e-postası gönderilemedi')
+    } finally {
+      setSending(false)
+    }
+  }
+
+  return (
+    <div className="flex flex-col gap-4 rounded-3xl border border-amber-200 bg-amber-50/90 p-6 text-sm text-amber-800 sm:flex-row sm:items-center sm:justify-between">
+      <div className="flex items-start gap-3">
+        <MailWarning className="mt-0.5 h-5 w-5 flex-none" />
+        <p>
+          <span className="font-semibold">{email}</span> adresini doğrulayana kadar profilin herkese açık görünmez, bağış
+          alamaz ve para çekemezsin.
+        </p>
+      </div>
+      <button
+        type="button"
+        onClick={handleResend}
+        disabled={sending}
+        className="inline-flex flex-none items-center justify-center rounded-2xl bg-amber-600 px-4 py-2 text-xs font-semibold text-white transition hover:bg-amber-500 disabled:cursor-not-allowed disabled:opacity-60"
+      >
+        {sending ? 'Gönderiliyor…' : 'Bağlantıyı tekrar gönder'}
+      </button>
+    </div>
+  )
+}
+
+export default EmailVerificationNotice
@@ -32,8 +32,8 @@ const Navbar = () => {
     }
   }, [menuOpen])
 
-  const handleLogout = () => {
-    logout()
+  const handleLogout = async () => {
+    await logout()
     navigate('/login')
   }
 
@@ -1,5 +1,6 @@
 import { createContext, useCallback, useEffect, useMemo, useState } from 'react'
-import { fetchCurrentUser, loginUser, registerUser } from '../api/auth'
+import { fetchCurrentUser, loginUser, logoutUser, registerUser } from '../api/auth'
+import { TOKEN_KEY, clearTokens, storeTokens } from '../api/axiosInstance'
 
 export const AuthContext = createContext(null)
 
@@ -8,7 +9,7 @@ export const AuthProvider = ({ children }) => {
   const [loading, setLoading] = useState(true)
 
   const bootstrap = useCallback(async () => {
-    const token = window.localStorage.getItem('donation_token')
+    const token = window.localStorage.getItem(TOKEN_KEY)
     if (!token) {
       setLoading(false)
       return
@@ -18,7 +19,7 @@ export const AuthProvider = ({ children }) => {
       const userResponse = await fetchCurrentUser()
       setUser(userResponse.user)
     } catch (error) {
-      window.localStorage.removeItem('donation_token')
+      clearTokens()
     } finally {
       setLoading(false)
     }
@@ -34,7 +35,7 @@ export const AuthProvider = ({ children }) => {
       setUser(freshUser)
       return freshUser
     } catch (error) {
-      window.localStorage.removeItem('donation_token')
+      clearTokens()
       setUser(null)
       throw error
     }
@@ -42,21 +43,27 @@ export const AuthProvider = ({ children }) => {
 
   const handleLogin = useCallback(async (credentials) => {
     const data = await loginUser(credentials)
-    window.localStorage.setItem('donation_token', data.token)
+    storeTokens(data)
     setUser(data.user)
     return data.user
   }, [])
 
   const handleRegister = useCallback(async (payload) => {
     const data = await registerUser(payload)
-    window.localStorage.setItem('donation_token', data.token)
+    storeTokens(data)
     setUser(data.user)
     return data.user
   }, [])
 
-  const logout = useCallback(() => {
-    window.localStorage.removeItem('donation_token')
-    setUser(null)
+  const logout = useCallback(async () => {
+    try {
+      await logoutUser()
+    } catch (error) {
+      console.error('logout error', error)
+    } finally {
+      clearTokens()
+      setUser(null)
+    }
   }, [])
 
   const value = useMemo(
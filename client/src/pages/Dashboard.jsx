@@ -2,6 +2,7 @@ import { useEffect, useState } from 'react'
 import { ImageDown, Loader2, MessageSquare } from 'lucide-react'
 import toast from 'react-hot-toast'
 import { updateProfile } from '../api/auth'
+import EmailVerificationNotice from '../components/EmailVerificationNotice.jsx'
 import useAuth from '../hooks/useAuth'
 
 const Dashboard = () => {
@@ -80,6 +81,8 @@ const Dashboard = () => {
         </p>
       </div>
 
+      {user && !user.emailVerified && <EmailVerificationNotice email={user.email} />}
+
       <form
         onSubmit={handleSubmit}
         className="grid gap-10 rounded-[32px] border border-slate-200/80 bg-white/90 p-8 shadow-soft backdrop-blur md:grid-cols-[1.2fr,0.8fr] lg:p-12"
@@ -102,6 +102,10 @@ const Login = () => {
             </div>
           </label>
 
+          <Link to="/reset-password" className="-mt-3 self-end text-xs font-semibold text-slate-500 underline hover:text-slate-900">
+            Şifreni mi unuttun?
+          </Link>
+
           <button
             type="submit"
             disabled={loading}
@@ -0,0 +1,144 @@
+import { useState } from 'react'
+import { Lock, Mail } from 'lucide-react'
+import { Link, useNavigate, useSearchParams } from 'react-router-dom'
+import toast from 'react-hot-toast'
+import { requestPasswordReset, resetPassword } from '../api/auth'
+
+const inputClassName =
+  'w-full rounded-2xl border border-slate-200 bg-white px-12 py-3 text-sm text-slate-900 shadow-sm transition focus:border-slate-900 focus:outline-none focus:ring-4 focus:ring-accent/10'
+
+const ResetPassword = () => {
+  const [searchParams] = useSearchParams()
+  const token = searchParams.get('token') ?? ''
+  const [email, setEmail] = useState('')
+  const [form, setForm] = useState({ password: '', confirm: '' })
+  const [loading, setLoading] = useState(false)
+  const [sent, setSent] = useState(false)
+  const navigate = useNavigate()
+
+  const handleRequest = async (event) => {
+    event.preventDefault()
+    setLoading(true)
+    try {
+      await requestPasswordReset(email)
+      setSent(true)
+    } catch (error) {
+      toast.error(error.response?.data?.message ?? 'İstek gönderilemedi')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const handleReset = async (event) => {
+    event.preventDefault()
+    if (form.password !== form.confirm) {
+      toast.error('Şifreler eşleşmiyor')
+      return
+    }
+    setLoading(true)
+    try {
+      await resetPassword({ token, password: form.password })
+      toast.success('Şifren güncellendi, yeni şifrenle giriş yapabilirsin')
+      navigate('/login')
+    } catch (error) {
+      toast.error(error.response?.data?.message ?? 'Şifre güncellenemedi')
+    } finally {
+      setLoading(false)
+    }
+  }
+
+  const handleChange = (event) => {
+    const { name, value } = event.target
+    setForm((prev) => ({ ...prev, [name]: value }))
+  }
+
+  return (
+    <main className="relative mx-auto flex w-full max-w-lg flex-col px-4 py-16 sm:px-6 lg:px-8">
+      <form
+        onSubmit={token ? handleReset : handleRequest}
+        className="flex flex-col gap-6 rounded-3xl border border-slate-200 bg-white/90 p-8 shadow-soft backdrop-blur"
+      >
+        <div>
+          <h1 className="text-xl font-semibold text-slate-900">{token ? 'Yeni şifre belirle' : 'Şifreni sıfırla'}</h1>
+          <p className="mt-1 text-sm text-slate-500">
+            {token
+              ? 'Yeni şifreni belirlediğinde tüm cihazlardaki oturumların kapatılır.'
+              : 'Kayıtlı e-posta adresine tek kullanımlık bir sıfırlama bağlantısı gönderelim.'}
+          </p>
+        </div>
+
+        {token ? (
+          <>
+            <label className="space-y-2 text-sm text-slate-700">
+              <span className="font-medium">Yeni şifre</span>
+              <div className="relative">
+                <Lock className="absolute left-4 top-1/2 h-4 w-4 -translate-y-1/2 text-slate-400" />
+                <input
+                  type="password"
+                  name="password"
+                  required
+                  minLength={6}
+                  value={form.password}
+                  onChange={handleChange}
+                  className={inputClassName}
+                  placeholder="••••••••"
+                />
+              </div>
+            </label>
+            <label className="space-y-2 text-sm text-slate-700">
+              <span className="font-medium">Yeni şifre (tekrar)</span>
+              <div className="relative">
+                <Lock className="absolute left-4 top-1/2 h-4 w-4 -translate-y-1/2 text-slate-400" />
+                <input
+                  type="password"
+                  name="confirm"
+                  required
+                  minLength={6}
+                  value={form.confirm}
+                  onChange={handleChange}
+                  className={inputClassName}
+                  placeholder="••••••••"
+                />
+              </div>
+            </label>
+          </>
+        ) : sent ? (
+          <p className="rounded-2xl border border-emerald-200 bg-emerald-50 px-4 py-3 text-sm text-emerald-700">
+            Bu e-posta kayıtlıysa birkaç dakika içinde sıfırlama bağlantısı gelecek. Gelen kutunu kontrol et.
+          </p>
+        ) : (
+          <label className="space-y-2 text-sm text-slate-700">
+            <span className="font-medium">E-posta</span>
+            <div className="relative">
+              <Mail className="absolute left-4 top-1/2 h-4 w-4 -translate-y-1/2 text-slate-400" />
+              <input
+                type="email"
+                required
+                value={email}
+                onChange={(event) => setEmail(event.target.value)}
+                className={inputClassName}
+                placeholder="sen@orneksite.com"
+              />
+            </div>
+          </label>
+        )}
+
+        {!sent && (
+          <button
+            type="submit"
+            disabled={loading}
+            className="inline-flex w-full items-center justify-center gap-2 rounded-2xl bg-slate-900 px-5 py-3 text-sm font-semibold text-white shadow-sm shadow-slate-900/25 transition hover:bg-slate-700 disabled:cursor-not-allowed disabled:opacity-60"
+          >
+            {loading ? 'Gönderiliyor…' : token ? 'Şifreyi Güncelle' : 'Bağlantı Gönder'}
+          </button>
+        )}
+
+        <Link to="/login" className="text-center text-xs font-semibold text-slate-500 underline hover:text-slate-900">
+          Girişe dön
+        </Link>
+      </form>
+    </main>
+  )
+}
+
+export default ResetPassword
@@ -0,0 +1,52 @@
+import { useEffect, useRef, useState } from 'react'
+import { CheckCircle2, Loader2, XCircle } from 'lucide-react'
+import { Link, useSearchParams } from 'react-router-dom'
+import { verifyEmail } from '../api/auth'
+import useAuth from '../hooks/useAuth'
+
+const VerifyEmail = () => {
+  const [searchParams] = useSearchParams()
+  const token = searchParams.get('token') ?? ''
+  const [status, setStatus] = useState(token ? 'loading' : 'error')
+  const [message, setMessage] = useState(token ? '' : 'Doğrulama bağlantısı eksik.')
+  const { isAuthenticated, refreshUser } = useAuth()
+  const requested = useRef(false)
+
+  useEffect(() => {
+    if (!token || requested.current) return
+    requested.current = true
+
+    verifyEmail(token)
+      .then((data) => {
+        setStatus('success')
+        setMessage(data.message ?? 'E-posta adresin doğrulandı.')
+        if (isAuthenticated) {
+          refreshUser().catch(() => {})
+        }
+      })
+      .catch((error) => {
+        setStatus('error')
+        setMessage(error.response?.data?.message ?? 'Doğrulama bağlantısı geçersiz veya süresi dolmuş.')
+      })
+  }, [token, isAuthenticated, refreshUser])
+
+  return (
+    <main className="relative mx-auto flex w-full max-w-lg flex-col px-4 py-16 sm:px-6 lg:px-8">
+      <div className="flex flex-col items-center gap-4 rounded-3xl border border-slate-200 bg-white/90 p-8 text-center shadow-soft backdrop-blur">
+        {status === 'loading' && <Loader2 className="h-8 w-8 animate-spin text-slate-400" />}
+        {status === 'success' && <CheckCircle2 className="h-8 w-8 text-emerald-500" />}
+        {status === 'error' && <XCircle className="h-8 w-8 text-rose-500" />}
+        <h1 className="text-xl font-semibold text-slate-900">E-posta doğrulama</h1>
+        <p className="text-sm text-slate-600">{status === 'loading' ? 'Bağlantın kontrol ediliyor…' : message}</p>
+        <Link
+          to={isAuthenticated ? '/dashboard' : '/login'}
+          className="text-xs font-semibold text-slate-500 underline hover:text-slate-900"
+        >
+          {isAuthenticated ? 'Panele dön' : 'Giriş yap'}
+        </Link>
+      </div>
+    </main>
+  )
+}
+
+export default VerifyEmail
@@ -3,7 +3,7 @@ import { ExternalLink, Loader2, Sparkles, TrendingUp } from 'lucide-react'
 import toast from 'react-hot-toast'
 import { fetchWalletSummary } from '../api/auth'
 import useAuth from '../hooks/useAuth'
-import { formatCurrency } from '../utils/format'
+import { formatCurrency, toMajorUnits } from '../utils/format'
 
 const Wallet = () => {
   const [summary, setSummary] = useState({ wallet: 0, donations: [] })
@@ -101,11 +101,12 @@ const Wallet = () => {
                     {formatCurrency(
                       summary.donations
                         .filter((donation) => {
+                          if (donation.reversedAt) return false
                           const date = donation.date ? new Date(donation.date) : null
                           const diff = date ? (Date.now() - date.getTime()) / (1000 * 60 * 60 * 24) : Infinity
                           return diff <= 30
                         })
-                        .reduce((acc, donation) => acc + (donation.amount ?? 0), 0)
+                        .reduce((acc, donation) => acc + toMajorUnits(donation.amount), 0)
                     )}
                   </p>
                 </div>
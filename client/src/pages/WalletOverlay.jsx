@@ -3,7 +3,7 @@ import { Loader2 } from 'lucide-react'
 import { useSearchParams } from 'react-router-dom'
 import toast from 'react-hot-toast'
 import { fetchSelectedDonations } from '../api/auth'
-import { formatCurrency } from '../utils/format'
+import { formatCurrency, toMajorUnits } from '../utils/format'
 
 const AnimatedBackground = () => (
   <div className="pointer-events-none absolute inset-0 overflow-hidden" aria-hidden>
@@ -19,6 +19,7 @@ const WalletOverlay = () => {
   const [donations, setDonations] = useState([])
 
   const idsParam = searchParams.get('ids')
+  const overlayKey = searchParams.get('key')
   const ids = useMemo(
     () =>
       (idsParam || '')
@@ -36,7 +37,7 @@ const WalletOverlay = () => {
       }
       setLoading(true)
       try {
-        const { donations: selectedDonations } = await fetchSelectedDonations(ids)
+        const { donations: selectedDonations } = await fetchSelectedDonations(ids, { overlayKey })
         setDonations(selectedDonations ?? [])
       } catch (error) {
         console.error('selected donations error', error)
@@ -47,10 +48,10 @@ const WalletOverlay = () => {
     }
 
     loadDonations()
-  }, [ids])
+  }, [ids, overlayKey])
 
   const totalAmount = useMemo(
-    () => donations.reduce((sum, donation) => sum + (donation.amount ?? 0), 0),
+    () => donations.reduce((sum, donation) => sum + toMajorUnits(donation.amount), 0),
     [donations],
   )
 
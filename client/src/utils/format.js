@@ -1,7 +1,14 @@
+export const toMajorUnits = (value) => {
+  if (value && typeof value === 'object') {
+    return (value.minor ?? 0) / 100
+  }
+  return value ?? 0
+}
+
 export const formatCurrency = (value) =>
   new Intl.NumberFormat('tr-TR', {
     style: 'currency',
-    currency: 'TRY',
+    currency: (value && typeof value === 'object' && value.currency) || 'TRY',
     minimumFractionDigits: 2,
     maximumFractionDigits: 2,
-  }).format(value ?? 0)
+  }).format(toMajorUnits(value))
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const idempotencyKeyTTL = 24 * time.Hour
+
+var indexes = map[string][]mongo.IndexModel{
+	"ledger_entries": {
+		{Keys: bson.D{{Key: "lines.account", Value: 1}, {Key: "currency", Value: 1}, {Key: "createdAt", Value: 1}}},
+		{Keys: bson.D{{Key: "reference", Value: 1}}},
+	},
+	"donations": {
+		{Keys: bson.D{{Key: "toUserId", Value: 1}, {Key: "status", Value: 1}, {Key: "paidAt", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "toUserId", Value: 1}, {Key: "paidAt", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "toUserId", Value: 1}, {Key: "amount.minor", Value: -1}, {Key: "_id", Value: -1}}},
+		{Keys: bson.D{{Key: "fromUserId", Value: 1}, {Key: "paidAt", Value: -1}, {Key: "_id", Value: -1}}},
+	},
+	"payouts": {
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "requestedAt", Value: -1}}},
+		{Keys: bson.D{{Key: "status", Value: 1}, {Key: "requestedAt", Value: -1}}},
+	},
+	"overlay_keys": {
+		{Keys: bson.D{{Key: "keyHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	},
+	"goals": {
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}}},
+	},
+	"sessions": {
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "lastSeenAt", Value: -1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"refresh_tokens": {
+		{Keys: bson.D{{Key: "tokenHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "familyId", Value: 1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"password_resets": {
+		{Keys: bson.D{{Key: "tokenHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "userId", Value: 1}, {Key: "createdAt", Value: -1}}},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"alert_tickets": {
+		{Keys: bson.D{{Key: "ticketHash", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "expiresAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(0)},
+	},
+	"idempotency_keys": {
+		{Keys: bson.D{{Key: "scope", Value: 1}, {Key: "key", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "createdAt", Value: 1}}, Options: options.Index().SetExpireAfterSeconds(int32(idempotencyKeyTTL / time.Second))},
+	},
+}
+
+func EnsureIndexes(ctx context.Context) error {
+	for collection, models := range indexes {
+		if len(models) == 0 {
+			continue
+		}
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, models); err != nil {
+			return err
+		}
+	}
+	return nil
+}
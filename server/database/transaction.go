@@ -0,0 +1,30 @@
+package database
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// WithTransaction, fn içindeki tüm yazmaları tek bir oturum işleminde çalıştırır.
+// Sürücü TransientTransactionError ve UnknownTransactionCommitResult durumlarında
+// işlemi kendisi yeniden dener; fn bu yüzden yan etkisiz ve tekrarlanabilir olmalı.
+func WithTransaction(ctx context.Context, fn func(sessCtx mongo.SessionContext) error) error {
+	session, err := client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	txnOptions := options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.Majority())
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	}, txnOptions)
+	return err
+}
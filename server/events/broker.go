@@ -0,0 +1,77 @@
+package events
+
+import (
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const subscriberBuffer = 32
+
+type Event struct {
+	ID   string      `json:"id"`
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+	// Revision, aynı kimlikle yeniden yayınlanan olayı (ör. onaylanan mesaj) öncekinden ayırır.
+	Revision string `json:"-"`
+}
+
+// Broker, yaratıcı bazında süreç içi yayın/abone dağıtımı yapar. Yavaş aboneler yayını
+// bekletmesin diye tamponu dolu olan aboneye giden olay atlanır; istemci Last-Event-ID
+// ile yeniden bağlandığında eksikleri veritabanından tamamlar.
+type Broker struct {
+	mu          sync.RWMutex
+	subscribers map[primitive.ObjectID]map[chan Event]struct{}
+}
+
+func NewBroker() *Broker {
+	return &Broker{subscribers: make(map[primitive.ObjectID]map[chan Event]struct{})}
+}
+
+var defaultBroker = NewBroker()
+
+func Subscribe(creatorID primitive.ObjectID) (<-chan Event, func()) {
+	return defaultBroker.Subscribe(creatorID)
+}
+
+func Publish(creatorID primitive.ObjectID, event Event) {
+	defaultBroker.Publish(creatorID, event)
+}
+
+func (b *Broker) Subscribe(creatorID primitive.ObjectID) (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mu.Lock()
+	if b.subscribers[creatorID] == nil {
+		b.subscribers[creatorID] = make(map[chan Event]struct{})
+	}
+	b.subscribers[creatorID][ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers[creatorID], ch)
+			if len(b.subscribers[creatorID]) == 0 {
+				delete(b.subscribers, creatorID)
+			}
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (b *Broker) Publish(creatorID primitive.ObjectID, event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for ch := range b.subscribers[creatorID] {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package fees
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"donation-app/server/models"
+)
+
+const basisPointsPerUnit = 10000
+
+var (
+	ErrFeesExceedAmount      = errors.New("fees exceed donation amount")
+	ErrFixedFeeNotConfigured = errors.New("fixed fee is not configured for currency")
+)
+
+// FixedFees, sabit ücretleri para birimi kodu başına küçük birim olarak tutar.
+type FixedFees map[string]int64
+
+// Schedule, yüzdeleri baz puan (1 = %0.01), sabit ücretleri para birimi başına küçük birim olarak tutar.
+type Schedule struct {
+	PlatformBasisPoints  int64     `json:"platformBasisPoints"`
+	PlatformFixed        FixedFees `json:"platformFixed"`
+	ProcessorBasisPoints int64     `json:"processorBasisPoints"`
+	ProcessorFixed       FixedFees `json:"processorFixed"`
+}
+
+type Breakdown struct {
+	Gross        models.Money `json:"gross"`
+	PlatformFee  models.Money `json:"platformFee"`
+	ProcessorFee models.Money `json:"processorFee"`
+	Net          models.Money `json:"net"`
+}
+
+type tierConfig struct {
+	PlatformPercent  *string `json:"platformPercent"`
+	PlatformFixed    *string `json:"platformFixed"`
+	ProcessorPercent *string `json:"processorPercent"`
+	ProcessorFixed   *string `json:"processorFixed"`
+}
+
+var (
+	defaultSchedule Schedule
+	tierSchedules   = map[string]Schedule{}
+)
+
+func Setup() error {
+	schedule, err := scheduleFromConfig(Schedule{}, tierConfig{
+		PlatformPercent:  envPointer("FEE_PLATFORM_PERCENT", "5"),
+		PlatformFixed:    envPointer("FEE_PLATFORM_FIXED", "0"),
+		ProcessorPercent: envPointer("FEE_PROCESSOR_PERCENT", "0"),
+		ProcessorFixed:   envPointer("FEE_PROCESSOR_FIXED", "0"),
+	})
+	if err != nil {
+		return err
+	}
+
+	tiers := map[string]Schedule{}
+	if raw := strings.TrimSpace(os.Getenv("FEE_TIERS")); raw != "" {
+		var configs map[string]tierConfig
+		if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+			return fmt.Errorf("FEE_TIERS: %w", err)
+		}
+		for name, config := range configs {
+			tierSchedule, err := scheduleFromConfig(schedule, config)
+			if err != nil {
+				return fmt.Errorf("FEE_TIERS[%s]: %w", name, err)
+			}
+			tiers[strings.ToLower(name)] = tierSchedule
+		}
+	}
+
+	defaultSchedule = schedule
+	tierSchedules = tiers
+	return nil
+}
+
+// ForTier, yaratıcının seviyesine özel bir tarife varsa onu, yoksa varsayılan tarifeyi döndürür.
+func ForTier(tier string) Schedule {
+	if schedule, ok := tierSchedules[strings.ToLower(strings.TrimSpace(tier))]; ok {
+		return schedule
+	}
+	return defaultSchedule
+}
+
+func (s Schedule) Apply(gross models.Money) (Breakdown, error) {
+	platformFixed, err := s.PlatformFixed.For(gross.Currency)
+	if err != nil {
+		return Breakdown{}, err
+	}
+	processorFixed, err := s.ProcessorFixed.For(gross.Currency)
+	if err != nil {
+		return Breakdown{}, err
+	}
+
+	platform := percentOf(gross.Minor, s.PlatformBasisPoints) + platformFixed
+	processor := percentOf(gross.Minor, s.ProcessorBasisPoints) + processorFixed
+
+	net := gross.Minor - platform - processor
+	if net <= 0 {
+		return Breakdown{}, ErrFeesExceedAmount
+	}
+
+	return Breakdown{
+		Gross:        gross,
+		PlatformFee:  models.NewMoney(platform, gross.Currency),
+		ProcessorFee: models.NewMoney(processor, gross.Currency),
+		Net:          models.NewMoney(net, gross.Currency),
+	}, nil
+}
+
+// For, para biriminin sabit ücretini döndürür. Sabit ücret yalnızca bazı para birimleri için
+// tanımlıysa diğerlerinde başka birimdeki tutarı düşmek yerine işlem reddedilir.
+func (f FixedFees) For(currency string) (int64, error) {
+	if fee, ok := f[currency]; ok {
+		return fee, nil
+	}
+	for _, fee := range f {
+		if fee > 0 {
+			return 0, ErrFixedFeeNotConfigured
+		}
+	}
+	return 0, nil
+}
+
+// percentOf, yarım kuruşları yukarı yuvarlar.
+func percentOf(amount int64, basisPoints int64) int64 {
+	return (amount*basisPoints + basisPointsPerUnit/2) / basisPointsPerUnit
+}
+
+func scheduleFromConfig(base Schedule, config tierConfig) (Schedule, error) {
+	schedule := base
+	var err error
+
+	if config.PlatformPercent != nil {
+		if schedule.PlatformBasisPoints, err = parseBasisPoints(*config.PlatformPercent); err != nil {
+			return Schedule{}, err
+		}
+	}
+	if config.PlatformFixed != nil {
+		if schedule.PlatformFixed, err = parseFixedFees(*config.PlatformFixed); err != nil {
+			return Schedule{}, err
+		}
+	}
+	if config.ProcessorPercent != nil {
+		if schedule.ProcessorBasisPoints, err = parseBasisPoints(*config.ProcessorPercent); err != nil {
+			return Schedule{}, err
+		}
+	}
+	if config.ProcessorFixed != nil {
+		if schedule.ProcessorFixed, err = parseFixedFees(*config.ProcessorFixed); err != nil {
+			return Schedule{}, err
+		}
+	}
+
+	return schedule, nil
+}
+
+// parseBasisPoints, "2.49" gibi bir yüzdeyi 249 baz puana çevirir.
+func parseBasisPoints(percent string) (int64, error) {
+	whole, fraction, _ := strings.Cut(strings.TrimSpace(percent), ".")
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("invalid percent %q", percent)
+	}
+	fraction += strings.Repeat("0", 2-len(fraction))
+
+	value, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil || value < 0 || value > basisPointsPerUnit {
+		return 0, fmt.Errorf("invalid percent %q", percent)
+	}
+	return value, nil
+}
+
+// parseFixedFees, "TRY:0.25,USD:0.10" biçimini okur. Para birimi yazılmamış tek bir tutar
+// ("0.25") varsayılan para birimine aittir.
+func parseFixedFees(value string) (FixedFees, error) {
+	fixed := FixedFees{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		currency := models.DefaultCurrency
+		amount := part
+		if code, rest, ok := strings.Cut(part, ":"); ok {
+			normalized, err := models.NormalizeCurrency(code)
+			if err != nil {
+				return nil, fmt.Errorf("invalid fixed fee currency %q", code)
+			}
+			currency, amount = normalized, rest
+		}
+
+		money, err := models.ParseMoney(strings.TrimSpace(amount), currency)
+		if err != nil || money.Minor < 0 {
+			return nil, fmt.Errorf("invalid fixed fee %q", part)
+		}
+		if _, exists := fixed[currency]; exists {
+			return nil, fmt.Errorf("duplicate fixed fee for %s", currency)
+		}
+		fixed[currency] = money.Minor
+	}
+	return fixed, nil
+}
+
+func envPointer(key string, fallback string) *string {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		value = fallback
+	}
+	return &value
+}
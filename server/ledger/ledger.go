@@ -0,0 +1,223 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+)
+
+const collectionName = "ledger_entries"
+
+type Account string
+
+const (
+	// Ödeme sağlayıcısında ya da bankada platform adına tutulan para.
+	AccountClearing Account = "platform:clearing"
+	AccountFees     Account = "platform:fees"
+	// Ödeme kuruluşuna borçlu olunan işlem ücretleri.
+	AccountProcessorFees Account = "platform:processor_fees"
+	// Ledger'dan önce oluşmuş bakiyelerin ve elle yapılan düzeltmelerin karşı hesabı.
+	AccountAdjustments Account = "platform:adjustments"
+)
+
+func WalletAccount(userID primitive.ObjectID) Account {
+	return Account("wallet:" + userID.Hex())
+}
+
+// PayoutHoldAccount, onay bekleyen para çekme talepleri için cüzdandan ayrılan tutarı tutar.
+func PayoutHoldAccount(userID primitive.ObjectID) Account {
+	return Account("payout_hold:" + userID.Hex())
+}
+
+type Kind string
+
+const (
+	KindDonation   Kind = "donation"
+	KindFee        Kind = "fee"
+	KindPayout     Kind = "payout"
+	KindRefund     Kind = "refund"
+	KindAdjustment Kind = "adjustment"
+)
+
+type Line struct {
+	Account Account `bson:"account" json:"account"`
+	Debit   int64   `bson:"debit" json:"debit"`
+	Credit  int64   `bson:"credit" json:"credit"`
+}
+
+type Entry struct {
+	ID        primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Kind      Kind               `bson:"kind" json:"kind"`
+	Currency  string             `bson:"currency" json:"currency"`
+	Lines     []Line             `bson:"lines" json:"lines"`
+	Reference string             `bson:"reference,omitempty" json:"reference,omitempty"`
+	Memo      string             `bson:"memo,omitempty" json:"memo,omitempty"`
+	CreatedAt time.Time          `bson:"createdAt" json:"createdAt"`
+}
+
+var (
+	ErrUnbalanced   = errors.New("ledger entry is not balanced")
+	ErrEmptyEntry   = errors.New("ledger entry has no lines")
+	ErrInvalidLine  = errors.New("ledger line must have exactly one positive side")
+	ErrInvalidMoney = errors.New("ledger amount must be positive")
+)
+
+func (e Entry) Validate() error {
+	if len(e.Lines) < 2 {
+		return ErrEmptyEntry
+	}
+
+	var debits, credits int64
+	for _, line := range e.Lines {
+		if line.Debit < 0 || line.Credit < 0 || (line.Debit == 0) == (line.Credit == 0) {
+			return ErrInvalidLine
+		}
+		debits += line.Debit
+		credits += line.Credit
+	}
+
+	if debits != credits {
+		return ErrUnbalanced
+	}
+	return nil
+}
+
+// Post, kaydı doğrulayıp yazar. Cüzdan güncellemesiyle aynı işlemde kalması için
+// ctx olarak çağıranın oturum bağlamı verilmelidir.
+func Post(ctx context.Context, entry Entry) (Entry, error) {
+	if err := entry.Validate(); err != nil {
+		return Entry{}, err
+	}
+
+	if entry.ID.IsZero() {
+		entry.ID = primitive.NewObjectID()
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+
+	if _, err := database.Collection(collectionName).InsertOne(ctx, entry); err != nil {
+		return Entry{}, err
+	}
+	return entry, nil
+}
+
+// Move, amount kadar tutarı from hesabından to hesabına aktaran iki satırlı bir kayıt yazar.
+func Move(ctx context.Context, kind Kind, from Account, to Account, amount models.Money, reference string, memo string) (Entry, error) {
+	if !amount.IsPositive() {
+		return Entry{}, ErrInvalidMoney
+	}
+
+	return Post(ctx, Entry{
+		Kind:     kind,
+		Currency: amount.Currency,
+		Lines: []Line{
+			{Account: from, Debit: amount.Minor},
+			{Account: to, Credit: amount.Minor},
+		},
+		Reference: reference,
+		Memo:      memo,
+	})
+}
+
+// Balance, hesabın alacak bakiyesini (alacaklar - borçlar) döndürür; cüzdan hesapları
+// bu yüzden pozitif bakiye gösterir.
+func Balance(ctx context.Context, account Account, currency string) (models.Money, error) {
+	return balance(ctx, account, currency, bson.M{"lines.account": account, "currency": currency})
+}
+
+// BalanceBefore, hesabın verilen andan önceki kayıtlarla oluşan bakiyesidir; dökümlerde
+// açılış bakiyesi olarak kullanılır.
+func BalanceBefore(ctx context.Context, account Account, currency string, before time.Time) (models.Money, error) {
+	return balance(ctx, account, currency, bson.M{"lines.account": account, "currency": currency, "createdAt": bson.M{"$lt": before}})
+}
+
+func balance(ctx context.Context, account Account, currency string, match bson.M) (models.Money, error) {
+	cursor, err := database.Collection(collectionName).Aggregate(ctx, bson.A{
+		bson.M{"$match": match},
+		bson.M{"$unwind": "$lines"},
+		bson.M{"$match": bson.M{"lines.account": account}},
+		bson.M{"$group": bson.M{
+			"_id":     nil,
+			"debits":  bson.M{"$sum": "$lines.debit"},
+			"credits": bson.M{"$sum": "$lines.credit"},
+		}},
+	})
+	if err != nil {
+		return models.Money{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var doc struct {
+		Debits  int64 `bson:"debits"`
+		Credits int64 `bson:"credits"`
+	}
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&doc); err != nil {
+			return models.Money{}, err
+		}
+	}
+
+	return models.NewMoney(doc.Credits-doc.Debits, currency), cursor.Err()
+}
+
+// Entries, hesaba dokunan kayıtları [from, to) aralığında oluşturulma sırasıyla döndürür.
+// Sıfır zaman değerleri sınırsız kabul edilir; imleci kapatmak çağıranın sorumluluğudur.
+func Entries(ctx context.Context, account Account, currency string, from, to time.Time) (*mongo.Cursor, error) {
+	filter := bson.M{"lines.account": account, "currency": currency}
+	createdAt := bson.M{}
+	if !from.IsZero() {
+		createdAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		createdAt["$lt"] = to
+	}
+	if len(createdAt) > 0 {
+		filter["createdAt"] = createdAt
+	}
+
+	return database.Collection(collectionName).Find(ctx, filter,
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}, {Key: "_id", Value: 1}}),
+	)
+}
+
+func FindByReference(ctx context.Context, kind Kind, reference string) (Entry, error) {
+	var entry Entry
+	err := database.Collection(collectionName).FindOne(ctx, bson.M{"kind": kind, "reference": reference}).Decode(&entry)
+	return entry, err
+}
+
+// Reverse, orijinal kaydın borç ve alacak taraflarını değiştirerek ters kaydını yazar.
+func Reverse(ctx context.Context, original Entry, kind Kind, memo string) (Entry, error) {
+	lines := make([]Line, 0, len(original.Lines))
+	for _, line := range original.Lines {
+		lines = append(lines, Line{Account: line.Account, Debit: line.Credit, Credit: line.Debit})
+	}
+
+	return Post(ctx, Entry{
+		Kind:      kind,
+		Currency:  original.Currency,
+		Lines:     lines,
+		Reference: original.Reference,
+		Memo:      memo,
+	})
+}
+
+// Net, kaydın verilen hesabın alacak bakiyesini ne kadar değiştirdiğini döndürür.
+func (e Entry) Net(account Account) models.Money {
+	var net int64
+	for _, line := range e.Lines {
+		if line.Account == account {
+			net += line.Credit - line.Debit
+		}
+	}
+	return models.NewMoney(net, e.Currency)
+}
@@ -0,0 +1,84 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File, iletileri MAIL_DIR altında .eml dosyaları olarak saklar; yerel geliştirmede bağlantıları
+// e-posta sunucusu kurmadan açmak için kullanılır.
+type File struct {
+	dir  string
+	from *mail.Address
+}
+
+func newFileFromEnv() (Mailer, error) {
+	dir := strings.TrimSpace(os.Getenv("MAIL_DIR"))
+	if dir == "" {
+		dir = "tmp/mail"
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	from, err := mail.ParseAddress(fromAddress())
+	if err != nil {
+		return nil, fmt.Errorf("invalid MAIL_FROM: %w", err)
+	}
+
+	return &File{dir: dir, from: from}, nil
+}
+
+func (f *File) Name() string {
+	return "file"
+}
+
+func (f *File) Send(_ context.Context, message Message) error {
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), sanitizeFilename(to.Address))
+	path := filepath.Join(f.dir, name)
+	if err := os.WriteFile(path, buildMessage(f.from, to, message), 0o600); err != nil {
+		return err
+	}
+
+	log.Printf("mail to %s written to %s", to.Address, path)
+	return nil
+}
+
+// Log, yalnızca alıcıyı ve konuyu günlüğe yazar; ileti gövdesi tek kullanımlık bağlantılar
+// taşıdığı için yazılmaz. Bağlantıları görmek için file göndericisi kullanılmalıdır.
+type Log struct{}
+
+func newLog() (Mailer, error) {
+	return Log{}, nil
+}
+
+func (Log) Name() string {
+	return "log"
+}
+
+func (Log) Send(_ context.Context, message Message) error {
+	log.Printf("mail to %s: %s (body not logged)", message.To, message.Subject)
+	return nil
+}
+
+func sanitizeFilename(value string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_', r == '@':
+			return r
+		default:
+			return '_'
+		}
+	}, value)
+}
@@ -0,0 +1,87 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrNotConfigured = errors.New("mailer is not configured")
+
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+}
+
+// Mailer, işlemsel e-postaları gönderir. Üretimde SMTP, yerel geliştirmede dosya ya da
+// log çıkışı kullanılır.
+type Mailer interface {
+	Name() string
+	Send(ctx context.Context, message Message) error
+}
+
+var factories = map[string]func() (Mailer, error){
+	"smtp": newSMTPFromEnv,
+	"file": newFileFromEnv,
+	"log":  newLog,
+}
+
+// Dosya ve log çıkışları yalnızca geliştirme ortamında kullanılabilir; üretimde sıfırlama ve
+// doğrulama bağlantılarının sunucu diskine ya da günlüklerine düşmemesi gerekir.
+var devOnly = map[string]bool{
+	"file": true,
+	"log":  true,
+}
+
+var active Mailer
+
+// Setup, gönderici açıkça seçilmeden sunucunun başlamasına izin vermez.
+func Setup(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return errors.New("MAILER must be set (smtp, or file/log with APP_ENV=development)")
+	}
+
+	factory, ok := factories[name]
+	if !ok {
+		return fmt.Errorf("unknown mailer %q", name)
+	}
+	if devOnly[name] && !isDevelopment() {
+		return fmt.Errorf("mailer %q is only allowed with APP_ENV=development", name)
+	}
+
+	mailer, err := factory()
+	if err != nil {
+		return err
+	}
+
+	active = mailer
+	return nil
+}
+
+func Active() Mailer {
+	return active
+}
+
+// Send, etkin gönderici üzerinden iletiyi yollar.
+func Send(ctx context.Context, message Message) error {
+	if active == nil {
+		return ErrNotConfigured
+	}
+	return active.Send(ctx, message)
+}
+
+func isDevelopment() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("APP_ENV")), "development")
+}
+
+func fromAddress() string {
+	from := strings.TrimSpace(os.Getenv("MAIL_FROM"))
+	if from == "" {
+		from = "Donate <no-reply@localhost>"
+	}
+	return from
+}
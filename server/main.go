@@ -13,7 +13,13 @@ import (
 	"github.com/joho/godotenv"
 
 	"donation-app/server/database"
+	"donation-app/server/fees"
+	"donation-app/server/mailer"
+	"donation-app/server/migrations"
+	"donation-app/server/moderation"
+	"donation-app/server/payments"
 	"donation-app/server/routes"
+	"donation-app/server/utils"
 )
 
 func main() {
@@ -36,20 +42,43 @@ func main() {
 		log.Fatalf("MongoDB bağlantı hatası: %v", err)
 	}
 
+	migrateCtx, cancelMigrate := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancelMigrate()
+
+	if err := database.EnsureIndexes(migrateCtx); err != nil {
+		log.Fatalf("Index oluşturulamadı: %v", err)
+	}
+
+	if err := migrations.Run(migrateCtx, database.Database()); err != nil {
+		log.Fatalf("Migration hatası: %v", err)
+	}
+
+	if err := payments.Setup(os.Getenv("PAYMENT_PROVIDER")); err != nil {
+		log.Fatalf("Ödeme sağlayıcısı yapılandırılamadı: %v", err)
+	}
+
+	if err := fees.Setup(); err != nil {
+		log.Fatalf("Komisyon tarifesi okunamadı: %v", err)
+	}
+
+	if err := moderation.Setup(); err != nil {
+		log.Fatalf("Yasaklı kelime listesi yüklenemedi: %v", err)
+	}
+
+	if err := mailer.Setup(os.Getenv("MAILER")); err != nil {
+		log.Fatalf("E-posta gönderici yapılandırılamadı: %v", err)
+	}
+
 	app := fiber.New()
 	app.Use(recover.New())
 	app.Use(logger.New())
 
-	allowedOrigin := os.Getenv("FRONTEND_URL")
-	if allowedOrigin == "" {
-		allowedOrigin = "http://localhost:5173"
-	}
-
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     allowedOrigin,
+		AllowOrigins:     utils.FrontendURL(),
 		AllowCredentials: true,
-		AllowHeaders:     "Content-Type, Authorization",
-		AllowMethods:     "GET,POST,PUT,OPTIONS",
+		AllowHeaders:     "Content-Type, Authorization, Idempotency-Key, X-Overlay-Key",
+		ExposeHeaders:    "Idempotent-Replayed",
+		AllowMethods:     "GET,POST,PUT,DELETE,OPTIONS",
 	}))
 
 	app.Get("/health", func(c *fiber.Ctx) error {
@@ -60,6 +89,13 @@ func main() {
 	routes.RegisterAuthRoutes(api.Group("/auth"))
 	routes.RegisterUserRoutes(api.Group("/users"))
 	routes.RegisterDonationRoutes(api)
+	routes.RegisterPaymentRoutes(api.Group("/payments"))
+	routes.RegisterPayoutRoutes(api.Group("/payouts"))
+	routes.RegisterOverlayRoutes(api.Group("/overlay"))
+	routes.RegisterOverlayKeyRoutes(api.Group("/overlay-keys"))
+	routes.RegisterAlertRoutes(api.Group("/alerts"))
+	routes.RegisterGoalRoutes(api.Group("/goals"))
+	routes.RegisterAdminRoutes(api.Group("/admin"))
 
 	port := os.Getenv("PORT")
 	if port == "" {
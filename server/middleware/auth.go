@@ -15,6 +15,8 @@ import (
 	"donation-app/server/utils"
 )
 
+const sessionTouchInterval = time.Minute
+
 func Protected() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		authHeader := c.Get("Authorization")
@@ -22,40 +24,105 @@ func Protected() fiber.Handler {
 			return utils.Error(c, fiber.StatusUnauthorized, "Yetkisiz erişim")
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			return utils.Error(c, fiber.StatusUnauthorized, "Geçersiz yetki başlığı")
+		user, session, status, message := authenticate(authHeader, c.IP())
+		if status != 0 {
+			return utils.Error(c, status, message)
 		}
 
-		token, err := utils.ValidateToken(parts[1])
-		if err != nil || !token.Valid {
-			return utils.Error(c, fiber.StatusUnauthorized, "Oturum süresi doldu")
-		}
+		c.Locals("user", user)
+		c.Locals("session", session)
+		return c.Next()
+	}
+}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return utils.Error(c, fiber.StatusUnauthorized, "Geçersiz oturum")
+// OptionalAuth, başlık yoksa isteği misafir olarak geçirir; başlık varsa Protected gibi doğrular.
+func OptionalAuth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		authHeader := c.Get("Authorization")
+		if authHeader == "" {
+			return c.Next()
 		}
 
-		userID, ok := claims["sub"].(string)
-		if !ok {
-			return utils.Error(c, fiber.StatusUnauthorized, "Geçersiz oturum")
+		user, session, status, message := authenticate(authHeader, c.IP())
+		if status != 0 {
+			return utils.Error(c, status, message)
 		}
 
-		objectID, err := primitive.ObjectIDFromHex(userID)
-		if err != nil {
-			return utils.Error(c, fiber.StatusUnauthorized, "Geçersiz kullanıcı")
-		}
+		c.Locals("user", user)
+		c.Locals("session", session)
+		return c.Next()
+	}
+}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
+// authenticate, erişim belirtecini ve bağlı olduğu oturumu doğrular. Oturum çıkışla ya da
+// uzaktan iptal edildiyse belirteç süresi dolmamış olsa bile reddedilir.
+func authenticate(authHeader string, ip string) (models.User, models.Session, int, string) {
+	parts := strings.Split(authHeader, " ")
+	if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Geçersiz yetki başlığı"
+	}
 
-		var user models.User
-		if err := database.Collection("users").FindOne(ctx, bson.M{"_id": objectID}).Decode(&user); err != nil {
-			return utils.Error(c, fiber.StatusUnauthorized, "Kullanıcı bulunamadı")
-		}
+	token, err := utils.ValidateToken(parts[1])
+	if err != nil || !token.Valid {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Oturum süresi doldu"
+	}
 
-		c.Locals("user", user)
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Geçersiz oturum"
+	}
+
+	userID, ok := claims["sub"].(string)
+	if !ok {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Geçersiz oturum"
+	}
+
+	objectID, err := primitive.ObjectIDFromHex(userID)
+	if err != nil {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Geçersiz kullanıcı"
+	}
+
+	// sid taşımayan eski belirteçler sunucuda takip edilmediği için kabul edilmez.
+	sessionClaim, _ := claims["sid"].(string)
+	sessionID, err := primitive.ObjectIDFromHex(sessionClaim)
+	if err != nil {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Oturum süresi doldu"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var session models.Session
+	if err := database.Collection("sessions").FindOne(ctx, bson.M{"_id": sessionID, "userId": objectID}).Decode(&session); err != nil {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Oturum bulunamadı"
+	}
+	now := time.Now().UTC()
+	if !session.IsActive(now) {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Oturum sonlandırıldı"
+	}
+
+	var user models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"_id": objectID}).Decode(&user); err != nil {
+		return models.User{}, models.Session{}, fiber.StatusUnauthorized, "Kullanıcı bulunamadı"
+	}
+
+	if now.Sub(session.LastSeenAt) > sessionTouchInterval || session.IP != ip {
+		_, _ = database.Collection("sessions").UpdateByID(ctx, session.ID, bson.M{"$set": bson.M{"lastSeenAt": now, "ip": ip}})
+		session.LastSeenAt, session.IP = now, ip
+	}
+
+	return user, session, 0, ""
+}
+
+func AdminOnly() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		user, ok := c.Locals("user").(models.User)
+		if !ok {
+			return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+		}
+		if !user.IsAdmin() {
+			return utils.Error(c, fiber.StatusForbidden, "Bu işlem için yetkin yok")
+		}
 		return c.Next()
 	}
 }
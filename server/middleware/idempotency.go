@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const maxIdempotencyKeyLen = 255
+
+type idempotencyRecord struct {
+	Scope       string    `bson:"scope"`
+	Key         string    `bson:"key"`
+	RequestHash string    `bson:"requestHash"`
+	Completed   bool      `bson:"completed"`
+	Status      int       `bson:"status,omitempty"`
+	ContentType string    `bson:"contentType,omitempty"`
+	Body        []byte    `bson:"body,omitempty"`
+	CreatedAt   time.Time `bson:"createdAt"`
+}
+
+// Idempotency, Idempotency-Key başlığıyla gelen isteğin ilk yanıtını saklar ve aynı
+// anahtarla tekrarlanan isteklere handler'ı çalıştırmadan aynı yanıtı döndürür.
+// Kullanıcıyı tanıyabilmesi için Protected'dan sonra eklenmelidir.
+func Idempotency() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Get("Idempotency-Key"))
+		if key == "" {
+			return c.Next()
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			return utils.Error(c, fiber.StatusBadRequest, "Idempotency-Key çok uzun")
+		}
+
+		scope := "ip:" + c.IP()
+		if user, ok := c.Locals("user").(models.User); ok {
+			scope = "user:" + user.ID.Hex()
+		}
+
+		digest := sha256.New()
+		digest.Write([]byte(c.Method() + " " + c.Path() + "\n"))
+		digest.Write(c.Body())
+		requestHash := hex.EncodeToString(digest.Sum(nil))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		records := database.Collection("idempotency_keys")
+		filter := bson.M{"scope": scope, "key": key}
+
+		_, err := records.InsertOne(ctx, idempotencyRecord{
+			Scope:       scope,
+			Key:         key,
+			RequestHash: requestHash,
+			CreatedAt:   time.Now().UTC(),
+		})
+		if mongo.IsDuplicateKeyError(err) {
+			var existing idempotencyRecord
+			if err := records.FindOne(ctx, filter).Decode(&existing); err != nil {
+				return utils.Error(c, fiber.StatusConflict, "İstek işleniyor, lütfen tekrar deneyin")
+			}
+			if existing.RequestHash != requestHash {
+				return utils.Error(c, fiber.StatusUnprocessableEntity, "Bu Idempotency-Key farklı bir istek için kullanılmış")
+			}
+			if !existing.Completed {
+				return utils.Error(c, fiber.StatusConflict, "Aynı istek hâlâ işleniyor")
+			}
+
+			c.Set("Idempotent-Replayed", "true")
+			if existing.ContentType != "" {
+				c.Set(fiber.HeaderContentType, existing.ContentType)
+			}
+			return c.Status(existing.Status).Send(existing.Body)
+		}
+		if err != nil {
+			return utils.Error(c, fiber.StatusInternalServerError, "İstek kaydedilemedi")
+		}
+
+		handlerErr := c.Next()
+		status := c.Response().StatusCode()
+
+		storeCtx, storeCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer storeCancel()
+
+		// Sunucu hatalarında kayıt silinir ki istemci aynı anahtarla yeniden deneyebilsin.
+		if handlerErr != nil || status >= fiber.StatusInternalServerError {
+			if _, err := records.DeleteOne(storeCtx, filter); err != nil {
+				log.Printf("idempotency cleanup error: %v", err)
+			}
+			return handlerErr
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		if _, err := records.UpdateOne(storeCtx, filter, bson.M{"$set": bson.M{
+			"completed":   true,
+			"status":      status,
+			"contentType": string(c.Response().Header.ContentType()),
+			"body":        body,
+		}}); err != nil {
+			log.Printf("idempotency store error: %v", err)
+		}
+
+		return nil
+	}
+}
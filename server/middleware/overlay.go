@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const overlayKeyTouchInterval = time.Minute
+
+// OverlayKey, OBS tarayıcı kaynaklarının URL'de taşıdığı salt okunur anahtarları doğrular.
+// Giriş JWT'si burada kabul edilmez; anahtar da Protected rotalarda işe yaramaz.
+func OverlayKey(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := strings.TrimSpace(c.Query("key"))
+		if key == "" {
+			key = strings.TrimSpace(c.Get("X-Overlay-Key"))
+		}
+		if key == "" {
+			return utils.Error(c, fiber.StatusUnauthorized, "Overlay anahtarı gerekli")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		var overlayKey models.OverlayKey
+		err := database.Collection("overlay_keys").FindOne(ctx, bson.M{
+			"keyHash":   utils.HashToken(key),
+			"revokedAt": bson.M{"$exists": false},
+		}).Decode(&overlayKey)
+		if err != nil {
+			return utils.Error(c, fiber.StatusUnauthorized, "Geçersiz overlay anahtarı")
+		}
+
+		if !overlayKey.HasScope(scope) {
+			return utils.Error(c, fiber.StatusForbidden, "Bu anahtar bu widget için yetkili değil")
+		}
+
+		now := time.Now().UTC()
+		if overlayKey.LastUsedAt == nil || now.Sub(*overlayKey.LastUsedAt) > overlayKeyTouchInterval {
+			_, _ = database.Collection("overlay_keys").UpdateByID(ctx, overlayKey.ID, bson.M{"$set": bson.M{"lastUsedAt": now}})
+		}
+
+		c.Locals("overlayKey", overlayKey)
+		return c.Next()
+	}
+}
@@ -0,0 +1,43 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/models"
+)
+
+var numericTypes = bson.A{"double", "int", "long", "decimal"}
+
+func toMinorUnits(field string) bson.M {
+	return bson.M{
+		"minor": bson.M{"$toLong": bson.M{"$round": bson.A{
+			bson.M{"$multiply": bson.A{"$" + field, 100}}, 0,
+		}}},
+		"currency": models.DefaultCurrency,
+	}
+}
+
+func moneyMinorUnits(ctx context.Context, db *mongo.Database) error {
+	if _, err := db.Collection("donations").UpdateMany(ctx,
+		bson.M{"amount": bson.M{"$type": numericTypes}},
+		bson.A{bson.M{"$set": bson.M{"amount": toMinorUnits("amount")}}},
+	); err != nil {
+		return err
+	}
+
+	if _, err := db.Collection("users").UpdateMany(ctx,
+		bson.M{"wallet": bson.M{"$type": numericTypes}},
+		bson.A{bson.M{"$set": bson.M{"wallet": toMinorUnits("wallet")}}},
+	); err != nil {
+		return err
+	}
+
+	_, err := db.Collection("users").UpdateMany(ctx,
+		bson.M{"wallet": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"wallet": models.Zero(models.DefaultCurrency)}},
+	)
+	return err
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/ledger"
+	"donation-app/server/models"
+)
+
+const openingBalanceMemo = "opening balance"
+
+func ledgerOpeningBalances(ctx context.Context, db *mongo.Database) error {
+	cursor, err := db.Collection("users").Find(ctx, bson.M{"wallet.minor": bson.M{"$ne": 0}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var user models.User
+		if err := cursor.Decode(&user); err != nil {
+			return err
+		}
+
+		existing, err := db.Collection("ledger_entries").CountDocuments(ctx, bson.M{
+			"kind":      ledger.KindAdjustment,
+			"reference": user.ID.Hex(),
+			"memo":      openingBalanceMemo,
+		})
+		if err != nil {
+			return err
+		}
+		if existing > 0 {
+			continue
+		}
+
+		wallet := ledger.WalletAccount(user.ID)
+		from, to, amount := ledger.AccountAdjustments, wallet, user.Wallet
+		if amount.IsNegative() {
+			from, to, amount = wallet, ledger.AccountAdjustments, amount.Neg()
+		}
+
+		if _, err := ledger.Move(ctx, ledger.KindAdjustment, from, to, amount, user.ID.Hex(), openingBalanceMemo); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/models"
+)
+
+// Ödeme sağlayıcısından önce kaydedilen bağışlar cüzdana anında eklendiği için ödenmiş sayılır.
+func donationStatus(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("donations").UpdateMany(ctx,
+		bson.M{"status": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"status": models.DonationPaid}},
+	)
+	return err
+}
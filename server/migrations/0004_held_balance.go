@@ -0,0 +1,19 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func heldBalance(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("users").UpdateMany(ctx,
+		bson.M{"heldBalance": bson.M{"$exists": false}},
+		bson.A{bson.M{"$set": bson.M{"heldBalance": bson.M{
+			"minor":    int64(0),
+			"currency": "$wallet.currency",
+		}}}},
+	)
+	return err
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Komisyonlardan önceki bağışların tamamı alıcıya yazıldığı için net tutar brüte eşittir.
+func donationFees(ctx context.Context, db *mongo.Database) error {
+	zero := bson.M{"minor": int64(0), "currency": "$amount.currency"}
+
+	_, err := db.Collection("donations").UpdateMany(ctx,
+		bson.M{"net": bson.M{"$exists": false}},
+		bson.A{bson.M{"$set": bson.M{
+			"platformFee":  zero,
+			"processorFee": zero,
+			"net":          "$amount",
+		}}},
+	)
+	return err
+}
@@ -0,0 +1,18 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Doğrulama zorunlu olmadan önce açılan hesaplar bağış almaya devam edebilsin diye doğrulanmış
+// sayılır; yeni kayıtlarda alan her zaman açıkça yazıldığı için yalnızca eski belgeler etkilenir.
+func emailVerified(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("users").UpdateMany(ctx,
+		bson.M{"emailVerified": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"emailVerified": true}},
+	)
+	return err
+}
@@ -0,0 +1,23 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/models"
+)
+
+// Durum geçişlerinden önceki bağışlar oluşturulduğu anda ödenmiş sayıldığı için paidAt
+// oluşturulma zamanıyla doldurulur; geçmiş ödeme zamanına göre sıralandığından gereklidir.
+func donationPaidAt(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("donations").UpdateMany(ctx,
+		bson.M{
+			"paidAt": bson.M{"$exists": false},
+			"status": bson.M{"$in": bson.A{models.DonationPaid, models.DonationRefunded, models.DonationChargedBack}},
+		},
+		bson.A{bson.M{"$set": bson.M{"paidAt": "$date"}}},
+	)
+	return err
+}
@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type migration struct {
+	ID string
+	Up func(ctx context.Context, db *mongo.Database) error
+}
+
+var all = []migration{
+	{ID: "0001_money_minor_units", Up: moneyMinorUnits},
+	{ID: "0002_ledger_opening_balances", Up: ledgerOpeningBalances},
+	{ID: "0003_donation_status", Up: donationStatus},
+	{ID: "0004_held_balance", Up: heldBalance},
+	{ID: "0005_donation_fees", Up: donationFees},
+	{ID: "0006_email_verified", Up: emailVerified},
+	{ID: "0007_donation_paid_at", Up: donationPaidAt},
+}
+
+// Run, henüz uygulanmamış göçleri sırayla çalıştırır ve her birini
+// "migrations" koleksiyonuna işler; böylece her göç yalnızca bir kez uygulanır.
+func Run(ctx context.Context, db *mongo.Database) error {
+	applied := db.Collection("migrations")
+
+	for _, m := range all {
+		err := applied.FindOne(ctx, bson.M{"_id": m.ID}).Err()
+		if err == nil {
+			continue
+		}
+		if err != mongo.ErrNoDocuments {
+			return err
+		}
+
+		log.Printf("Migration çalıştırılıyor: %s", m.ID)
+		if err := m.Up(ctx, db); err != nil {
+			return err
+		}
+
+		if _, err := applied.InsertOne(ctx, bson.M{"_id": m.ID, "appliedAt": time.Now().UTC()}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AlertQueue, yaratıcının overlay uyarı kuyruğunun kalıcı durumudur; _id yaratıcının kimliğidir.
+// OBS kaynağı yeniden bağlandığında oynatma buradan devam eder.
+type AlertQueue struct {
+	ID               primitive.ObjectID   `bson:"_id" json:"-"`
+	Paused           bool                 `bson:"paused" json:"paused"`
+	Current          *primitive.ObjectID  `bson:"current,omitempty" json:"current,omitempty"`
+	CurrentStartedAt *time.Time           `bson:"currentStartedAt,omitempty" json:"currentStartedAt,omitempty"`
+	Pending          []primitive.ObjectID `bson:"pending" json:"pending"`
+	Held             []primitive.ObjectID `bson:"held" json:"held"`
+	UpdatedAt        time.Time            `bson:"updatedAt" json:"updatedAt"`
+}
+
+// AlertTicket, tarayıcının WebSocket bağlantısına Authorization başlığı ekleyememesi nedeniyle
+// panel için verilen tek kullanımlık, kısa ömürlü giriş belgesidir.
+type AlertTicket struct {
+	ID         primitive.ObjectID `bson:"_id,omitempty"`
+	UserID     primitive.ObjectID `bson:"userId"`
+	TicketHash string             `bson:"ticketHash"`
+	ExpiresAt  time.Time          `bson:"expiresAt"`
+}
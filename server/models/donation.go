@@ -7,10 +7,76 @@ import (
 )
 
 type Donation struct {
-	ID         primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	FromUserID primitive.ObjectID `bson:"fromUserId,omitempty" json:"fromUserId,omitempty"`
-	ToUserID   primitive.ObjectID `bson:"toUserId" json:"toUserId"`
-	Amount     float64            `bson:"amount" json:"amount"`
-	Date       time.Time          `bson:"date" json:"date"`
-	SessionID  string             `bson:"sessionId" json:"-"`
+	ID              primitive.ObjectID   `bson:"_id,omitempty" json:"id"`
+	FromUserID      primitive.ObjectID   `bson:"fromUserId,omitempty" json:"fromUserId,omitempty"`
+	DonorName       string               `bson:"donorName,omitempty" json:"donorName,omitempty"`
+	DonorEmail      string               `bson:"donorEmail,omitempty" json:"donorEmail,omitempty"`
+	Anonymous       bool                 `bson:"anonymous,omitempty" json:"anonymous,omitempty"`
+	ToUserID        primitive.ObjectID   `bson:"toUserId" json:"toUserId"`
+	Amount          Money                `bson:"amount" json:"amount"`
+	PlatformFee     Money                `bson:"platformFee" json:"platformFee"`
+	ProcessorFee    Money                `bson:"processorFee" json:"processorFee"`
+	Net             Money                `bson:"net" json:"net"`
+	Message         string               `bson:"message,omitempty" json:"message,omitempty"`
+	DisplayName     string               `bson:"displayName,omitempty" json:"displayName,omitempty"`
+	MessageFiltered bool                 `bson:"messageFiltered,omitempty" json:"messageFiltered,omitempty"`
+	MessageReview   MessageReview        `bson:"messageReview,omitempty" json:"messageReview,omitempty"`
+	Date            time.Time            `bson:"date" json:"date"`
+	Status          DonationStatus       `bson:"status" json:"status"`
+	StatusChangedAt time.Time            `bson:"statusChangedAt,omitempty" json:"statusChangedAt,omitempty"`
+	PaidAt          *time.Time           `bson:"paidAt,omitempty" json:"paidAt,omitempty"`
+	Transitions     []DonationTransition `bson:"transitions,omitempty" json:"transitions,omitempty"`
+	Provider        string               `bson:"provider,omitempty" json:"provider,omitempty"`
+	SessionID       string               `bson:"sessionId" json:"-"`
+	PaymentRef      string               `bson:"paymentRef,omitempty" json:"paymentRef,omitempty"`
+	FailureReason   string               `bson:"failureReason,omitempty" json:"failureReason,omitempty"`
+	RefundClaimedAt *time.Time           `bson:"refundClaimedAt,omitempty" json:"-"`
+	ReviewReason    string               `bson:"reviewReason,omitempty" json:"reviewReason,omitempty"`
+	CapturedAmount  *Money               `bson:"capturedAmount,omitempty" json:"capturedAmount,omitempty"`
+	Receipt         *DonationReceipt     `bson:"receipt,omitempty" json:"receipt,omitempty"`
+}
+
+// ReviewAmountMismatch, sağlayıcının tahsil ettiği tutar bağışla eşleşmediğinde bağışa yazılır;
+// para tahsil edildiği için bağış "authorized" durumunda yönetici incelemesine bırakılır.
+const ReviewAmountMismatch = "amount_mismatch"
+
+// MessageReview, moderatör onayına bekletilen mesajın durumudur.
+type MessageReview string
+
+const (
+	MessageReviewHeld     MessageReview = "held"
+	MessageReviewApproved MessageReview = "approved"
+	MessageReviewRejected MessageReview = "rejected"
+)
+
+// MessagePublic, mesajın ve görünen adın overlay'e gönderilip gönderilemeyeceğini söyler.
+// Filtrelenmiş ya da bekletilen mesajlar ancak onaylandıktan sonra gösterilir.
+func (d Donation) MessagePublic() bool {
+	switch d.MessageReview {
+	case MessageReviewApproved:
+		return true
+	case MessageReviewHeld, MessageReviewRejected:
+		return false
+	default:
+		return !d.MessageFiltered
+	}
+}
+
+// DonationReceipt, bağış için ilk indirmede verilen makbuz numarasıdır; sonraki indirmeler
+// aynı numarayı kullanır.
+type DonationReceipt struct {
+	Number   string    `bson:"number" json:"number"`
+	Year     int       `bson:"year" json:"year"`
+	Sequence int64     `bson:"sequence" json:"sequence"`
+	IssuedAt time.Time `bson:"issuedAt" json:"issuedAt"`
+}
+
+// ForRecipient, alıcıya dönülen bağıştan bağışçının iletişim bilgisini ve anonimse kimliğini çıkarır.
+func (d Donation) ForRecipient() Donation {
+	d.DonorEmail = ""
+	if d.Anonymous {
+		d.FromUserID = primitive.NilObjectID
+		d.DonorName = ""
+	}
+	return d
 }
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+type DonationStatus string
+
+const (
+	DonationPending     DonationStatus = "pending"
+	DonationAuthorized  DonationStatus = "authorized"
+	DonationPaid        DonationStatus = "paid"
+	DonationFailed      DonationStatus = "failed"
+	DonationRefunded    DonationStatus = "refunded"
+	DonationChargedBack DonationStatus = "charged_back"
+	DonationCancelled   DonationStatus = "cancelled"
+)
+
+var ErrInvalidTransition = errors.New("invalid donation status transition")
+
+// Bağış durumları arasındaki tüm izinli geçişler yalnızca burada tanımlanır.
+var donationTransitions = map[DonationStatus][]DonationStatus{
+	DonationPending:    {DonationAuthorized, DonationPaid, DonationFailed, DonationCancelled},
+	DonationAuthorized: {DonationPaid, DonationFailed, DonationCancelled},
+	DonationPaid:       {DonationRefunded, DonationChargedBack},
+}
+
+type DonationTransition struct {
+	From   DonationStatus `bson:"from" json:"from"`
+	To     DonationStatus `bson:"to" json:"to"`
+	At     time.Time      `bson:"at" json:"at"`
+	Reason string         `bson:"reason,omitempty" json:"reason,omitempty"`
+}
+
+func (s DonationStatus) CanTransitionTo(next DonationStatus) bool {
+	for _, allowed := range donationTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s DonationStatus) IsTerminal() bool {
+	return len(donationTransitions[s]) == 0
+}
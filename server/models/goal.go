@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	MaxGoalTitleLength = 80
+	MaxGoalsPerUser    = 10
+)
+
+// Goal, yaratıcının bağış hedefidir. İlerleme saklanmaz; CycleStartedAt'ten sonra ödenen
+// bağışlardan hesaplanır. ResetOnReach açıksa hedefe ulaşıldığında yeni tur başlar.
+type Goal struct {
+	ID             primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	UserID         primitive.ObjectID `bson:"userId" json:"-"`
+	Title          string             `bson:"title" json:"title"`
+	Target         Money              `bson:"target" json:"target"`
+	StartAt        time.Time          `bson:"startAt" json:"startAt"`
+	EndAt          *time.Time         `bson:"endAt,omitempty" json:"endAt,omitempty"`
+	ResetOnReach   bool               `bson:"resetOnReach" json:"resetOnReach"`
+	CycleStartedAt time.Time          `bson:"cycleStartedAt" json:"cycleStartedAt"`
+	Completions    int                `bson:"completions" json:"completions"`
+	CreatedAt      time.Time          `bson:"createdAt" json:"createdAt"`
+	ArchivedAt     *time.Time         `bson:"archivedAt,omitempty" json:"archivedAt,omitempty"`
+}
+
+// ProgressWindowStart, ilerlemeye sayılacak ilk ödeme anıdır.
+func (g *Goal) ProgressWindowStart() time.Time {
+	if g.CycleStartedAt.After(g.StartAt) {
+		return g.CycleStartedAt
+	}
+	return g.StartAt
+}
+
+type GoalProgress struct {
+	Goal
+	Raised  Money `json:"raised"`
+	Percent int   `json:"percent"`
+	Reached bool  `json:"reached"`
+}
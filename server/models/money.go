@@ -0,0 +1,132 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const DefaultCurrency = "TRY"
+
+var currencyExponents = map[string]int{
+	"TRY": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+}
+
+var (
+	ErrInvalidAmount       = errors.New("invalid amount")
+	ErrUnsupportedCurrency = errors.New("unsupported currency")
+	ErrCurrencyMismatch    = errors.New("currency mismatch")
+)
+
+// Money, tutarı para biriminin en küçük biriminde (kuruş, cent) tam sayı olarak tutar.
+type Money struct {
+	Minor    int64  `bson:"minor" json:"minor"`
+	Currency string `bson:"currency" json:"currency"`
+}
+
+func NewMoney(minor int64, currency string) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return Money{Minor: 0, Currency: currency}
+}
+
+func NormalizeCurrency(currency string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if code == "" {
+		return DefaultCurrency, nil
+	}
+	if _, ok := currencyExponents[code]; !ok {
+		return "", ErrUnsupportedCurrency
+	}
+	return code, nil
+}
+
+// ParseMoney, "12.50" gibi ondalık bir metni float'a çevirmeden küçük birime dönüştürür.
+func ParseMoney(value string, currency string) (Money, error) {
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	exponent := currencyExponents[code]
+
+	value = strings.TrimSpace(strings.Replace(value, ",", ".", 1))
+	if value == "" || strings.HasPrefix(value, "-") || strings.HasPrefix(value, "+") {
+		return Money{}, ErrInvalidAmount
+	}
+
+	whole, fraction, _ := strings.Cut(value, ".")
+	if whole == "" {
+		whole = "0"
+	}
+	if len(fraction) > exponent {
+		return Money{}, ErrInvalidAmount
+	}
+	fraction += strings.Repeat("0", exponent-len(fraction))
+
+	minor, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidAmount
+	}
+
+	return Money{Minor: minor, Currency: code}, nil
+}
+
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.Minor > 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Minor < 0
+}
+
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Minor: m.Minor + other.Minor, Currency: m.Currency}, nil
+}
+
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Minor: m.Minor - other.Minor, Currency: m.Currency}, nil
+}
+
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+// Decimal, tutarı "1234.50" biçiminde, para birimi olmadan döndürür.
+func (m Money) Decimal() string {
+	exponent := currencyExponents[m.Currency]
+	minor := m.Minor
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+	if exponent == 0 {
+		return fmt.Sprintf("%s%d", sign, minor)
+	}
+
+	divisor := int64(1)
+	for i := 0; i < exponent; i++ {
+		divisor *= 10
+	}
+	return fmt.Sprintf("%s%d.%0*d", sign, minor/divisor, exponent, minor%divisor)
+}
+
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
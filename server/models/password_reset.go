@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// PasswordReset, e-postayla gönderilen tek kullanımlık şifre sıfırlama belirtecidir.
+// Belirtecin yalnızca özeti saklanır.
+type PasswordReset struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty"`
+	UserID        primitive.ObjectID `bson:"userId"`
+	TokenHash     string             `bson:"tokenHash"`
+	IP            string             `bson:"ip,omitempty"`
+	CreatedAt     time.Time          `bson:"createdAt"`
+	ExpiresAt     time.Time          `bson:"expiresAt"`
+	UsedAt        *time.Time         `bson:"usedAt,omitempty"`
+	InvalidatedAt *time.Time         `bson:"invalidatedAt,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type PayoutStatus string
+
+const (
+	PayoutRequested PayoutStatus = "requested"
+	PayoutCompleted PayoutStatus = "completed"
+	PayoutRejected  PayoutStatus = "rejected"
+)
+
+type Payout struct {
+	ID            primitive.ObjectID  `bson:"_id,omitempty" json:"id"`
+	UserID        primitive.ObjectID  `bson:"userId" json:"userId"`
+	Amount        Money               `bson:"amount" json:"amount"`
+	IBAN          string              `bson:"iban" json:"iban"`
+	AccountHolder string              `bson:"accountHolder" json:"accountHolder"`
+	Status        PayoutStatus        `bson:"status" json:"status"`
+	RequestedAt   time.Time           `bson:"requestedAt" json:"requestedAt"`
+	ProcessedAt   *time.Time          `bson:"processedAt,omitempty" json:"processedAt,omitempty"`
+	ProcessedBy   *primitive.ObjectID `bson:"processedBy,omitempty" json:"processedBy,omitempty"`
+	Reference     string              `bson:"reference,omitempty" json:"reference,omitempty"`
+	RejectReason  string              `bson:"rejectReason,omitempty" json:"rejectReason,omitempty"`
+}
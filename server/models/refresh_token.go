@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RefreshToken, tek kullanımlık yenileme belirtecidir. Her yenilemede aynı ailede yenisi
+// üretilir; kullanılmış bir belirteç tekrar gelirse bütün aile iptal edilir.
+type RefreshToken struct {
+	ID           primitive.ObjectID  `bson:"_id,omitempty"`
+	UserID       primitive.ObjectID  `bson:"userId"`
+	FamilyID     primitive.ObjectID  `bson:"familyId"`
+	TokenHash    string              `bson:"tokenHash"`
+	CreatedAt    time.Time           `bson:"createdAt"`
+	ExpiresAt    time.Time           `bson:"expiresAt"`
+	UsedAt       *time.Time          `bson:"usedAt,omitempty"`
+	ReplacedBy   *primitive.ObjectID `bson:"replacedBy,omitempty"`
+	RevokedAt    *time.Time          `bson:"revokedAt,omitempty"`
+	RevokeReason string              `bson:"revokeReason,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Session, bir girişin sunucu tarafındaki kaydıdır. Kimliği, erişim belirtecindeki sid ve
+// yenileme belirteci ailesinin kimliğiyle aynıdır.
+type Session struct {
+	ID           primitive.ObjectID `bson:"_id" json:"id"`
+	UserID       primitive.ObjectID `bson:"userId" json:"-"`
+	Device       string             `bson:"device" json:"device"`
+	IP           string             `bson:"ip" json:"ip"`
+	UserAgent    string             `bson:"userAgent" json:"userAgent"`
+	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	LastSeenAt   time.Time          `bson:"lastSeenAt" json:"lastSeenAt"`
+	ExpiresAt    time.Time          `bson:"expiresAt" json:"expiresAt"`
+	RevokedAt    *time.Time         `bson:"revokedAt,omitempty" json:"revokedAt,omitempty"`
+	RevokeReason string             `bson:"revokeReason,omitempty" json:"-"`
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
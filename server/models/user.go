@@ -6,58 +6,117 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
+const (
+	DefaultMessageMaxLength = 200
+	MaxMessageLength        = 500
+	MaxDisplayNameLength    = 40
+)
+
+// MinAmount omitempty taşımaz; Money.IsZero sıfır tutarı boş saydığından açıkça kaydedilen
+// "asgari tutar yok" ayarı yazılırken düşerdi.
+type MessageSettings struct {
+	MaxLength    int   `bson:"maxLength,omitempty" json:"maxLength"`
+	MinAmount    Money `bson:"minAmount" json:"minAmount"`
+	HoldMessages bool  `bson:"holdMessages,omitempty" json:"holdMessages"`
+}
+
 type User struct {
-	ID           primitive.ObjectID `bson:"_id,omitempty" json:"id"`
-	Name         string             `bson:"name" json:"name"`
-	Email        string             `bson:"email" json:"email"`
-	Username     string             `bson:"username" json:"username"`
-	PasswordHash string             `bson:"passwordHash" json:"-"`
-	Bio          string             `bson:"bio" json:"bio"`
-	ProfilePic   string             `bson:"profilePic" json:"profilePic"`
-	Wallet       float64            `bson:"wallet" json:"wallet"`
-	CreatedAt    time.Time          `bson:"createdAt" json:"createdAt"`
+	ID                 primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	Name               string             `bson:"name" json:"name"`
+	Email              string             `bson:"email" json:"email"`
+	EmailVerified      bool               `bson:"emailVerified" json:"emailVerified"`
+	EmailVerifiedAt    *time.Time         `bson:"emailVerifiedAt,omitempty" json:"-"`
+	VerificationSentAt *time.Time         `bson:"verificationSentAt,omitempty" json:"-"`
+	Username           string             `bson:"username" json:"username"`
+	PasswordHash       string             `bson:"passwordHash" json:"-"`
+	PasswordChangedAt  *time.Time         `bson:"passwordChangedAt,omitempty" json:"-"`
+	Bio                string             `bson:"bio" json:"bio"`
+	ProfilePic         string             `bson:"profilePic" json:"profilePic"`
+	Wallet             Money              `bson:"wallet" json:"wallet"`
+	NegativeBalance    bool               `bson:"negativeBalance,omitempty" json:"negativeBalance,omitempty"`
+	HeldBalance        Money              `bson:"heldBalance" json:"heldBalance"`
+	PayoutIBAN         string             `bson:"payoutIban,omitempty" json:"-"`
+	PayoutHolder       string             `bson:"payoutHolder,omitempty" json:"-"`
+	Role               string             `bson:"role,omitempty" json:"role,omitempty"`
+	Tier               string             `bson:"tier,omitempty" json:"tier,omitempty"`
+	MessageSettings    MessageSettings    `bson:"messageSettings,omitempty" json:"messageSettings"`
+	CreatedAt          time.Time          `bson:"createdAt" json:"createdAt"`
 }
 
 type SanitizedUser struct {
-	ID         primitive.ObjectID `json:"id"`
-	Name       string             `json:"name"`
-	Email      string             `json:"email"`
-	Username   string             `json:"username"`
-	Bio        string             `json:"bio"`
-	ProfilePic string             `json:"profilePic"`
-	Wallet     float64            `json:"wallet"`
-	CreatedAt  time.Time          `json:"createdAt"`
+	ID              primitive.ObjectID `json:"id"`
+	Name            string             `json:"name"`
+	Email           string             `json:"email"`
+	EmailVerified   bool               `json:"emailVerified"`
+	Username        string             `json:"username"`
+	Bio             string             `json:"bio"`
+	ProfilePic      string             `json:"profilePic"`
+	Wallet          Money              `json:"wallet"`
+	NegativeBalance bool               `json:"negativeBalance,omitempty"`
+	HeldBalance     Money              `json:"heldBalance"`
+	PayoutIBAN      string             `json:"payoutIban,omitempty"`
+	PayoutHolder    string             `json:"payoutHolder,omitempty"`
+	MessageSettings MessageSettings    `json:"messageSettings"`
+	CreatedAt       time.Time          `json:"createdAt"`
 }
 
 type PublicUser struct {
-	ID         primitive.ObjectID `json:"id"`
-	Name       string             `json:"name"`
-	Username   string             `json:"username"`
-	Bio        string             `json:"bio"`
-	ProfilePic string             `json:"profilePic"`
-	CreatedAt  time.Time          `json:"createdAt"`
+	ID              primitive.ObjectID `json:"id"`
+	Name            string             `json:"name"`
+	Username        string             `json:"username"`
+	Bio             string             `json:"bio"`
+	ProfilePic      string             `json:"profilePic"`
+	MessageSettings MessageSettings    `json:"messageSettings"`
+	CreatedAt       time.Time          `json:"createdAt"`
+}
+
+func (u *User) IsAdmin() bool {
+	return u.Role == RoleAdmin
+}
+
+func (u *User) MessageLimits() MessageSettings {
+	settings := u.MessageSettings
+	if settings.MaxLength <= 0 || settings.MaxLength > MaxMessageLength {
+		settings.MaxLength = DefaultMessageMaxLength
+	}
+	if settings.MinAmount.Currency == "" {
+		settings.MinAmount = Zero(u.Wallet.Currency)
+	}
+	return settings
 }
 
 func (u *User) Sanitize() SanitizedUser {
 	return SanitizedUser{
-		ID:         u.ID,
-		Name:       u.Name,
-		Email:      u.Email,
-		Username:   u.Username,
-		Bio:        u.Bio,
-		ProfilePic: u.ProfilePic,
-		Wallet:     u.Wallet,
-		CreatedAt:  u.CreatedAt,
+		ID:              u.ID,
+		Name:            u.Name,
+		Email:           u.Email,
+		EmailVerified:   u.EmailVerified,
+		Username:        u.Username,
+		Bio:             u.Bio,
+		ProfilePic:      u.ProfilePic,
+		Wallet:          u.Wallet,
+		NegativeBalance: u.NegativeBalance,
+		HeldBalance:     u.HeldBalance,
+		PayoutIBAN:      u.PayoutIBAN,
+		PayoutHolder:    u.PayoutHolder,
+		MessageSettings: u.MessageLimits(),
+		CreatedAt:       u.CreatedAt,
 	}
 }
 
 func (u *User) PublicProfile() PublicUser {
 	return PublicUser{
-		ID:         u.ID,
-		Name:       u.Name,
-		Username:   u.Username,
-		Bio:        u.Bio,
-		ProfilePic: u.ProfilePic,
-		CreatedAt:  u.CreatedAt,
+		ID:              u.ID,
+		Name:            u.Name,
+		Username:        u.Username,
+		Bio:             u.Bio,
+		ProfilePic:      u.ProfilePic,
+		MessageSettings: u.MessageLimits(),
+		CreatedAt:       u.CreatedAt,
 	}
 }
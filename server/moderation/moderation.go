@@ -0,0 +1,214 @@
+package moderation
+
+import (
+	"bufio"
+	_ "embed"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+//go:embed words_tr.txt
+var turkishWords string
+
+//go:embed words_en.txt
+var englishWords string
+
+var builtinLists = map[string]string{
+	"tr": turkishWords,
+	"en": englishWords,
+}
+
+// Tekrarlanan harfleri teke indirince kısa kelimeler sıradan kelimelerle çakıştığı için
+// yalnızca bu uzunluktaki sıkıştırılmış biçimler eşleştirilir.
+const minCollapsedLength = 4
+
+var accentReplacer = strings.NewReplacer(
+	"ç", "c", "ğ", "g", "ı", "i", "ö", "o", "ş", "s", "ü", "u",
+	"â", "a", "î", "i", "û", "u", "é", "e", "è", "e", "à", "a",
+)
+
+var leetReplacer = strings.NewReplacer(
+	"0", "o", "1", "i", "3", "e", "4", "a", "5", "s", "7", "t", "8", "b",
+	"@", "a", "$", "s", "!", "i", "|", "i", "€", "e",
+)
+
+type Filter struct {
+	words     map[string]struct{}
+	collapsed map[string]struct{}
+}
+
+type Result struct {
+	Text    string
+	Flagged bool
+}
+
+var active = &Filter{words: map[string]struct{}{}, collapsed: map[string]struct{}{}}
+
+// Setup, BANNED_WORDS_LANGS ile seçilen yerleşik listeleri ve varsa BANNED_WORDS_FILE
+// dosyasındaki ek kelimeleri yükler.
+func Setup() error {
+	filter := &Filter{words: map[string]struct{}{}, collapsed: map[string]struct{}{}}
+
+	langs := strings.TrimSpace(os.Getenv("BANNED_WORDS_LANGS"))
+	if langs == "" {
+		langs = "tr,en"
+	}
+	for _, lang := range strings.Split(langs, ",") {
+		if list, ok := builtinLists[strings.ToLower(strings.TrimSpace(lang))]; ok {
+			filter.addList(list)
+		}
+	}
+
+	if path := strings.TrimSpace(os.Getenv("BANNED_WORDS_FILE")); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		filter.addList(string(content))
+	}
+
+	active = filter
+	return nil
+}
+
+func Check(text string) Result {
+	return active.Check(text)
+}
+
+func (f *Filter) addList(list string) {
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		word := Normalize(line)
+		if word == "" {
+			continue
+		}
+		f.words[word] = struct{}{}
+		if collapsed := collapseRepeats(word); utf8.RuneCountInString(collapsed) >= minCollapsedLength {
+			f.collapsed[collapsed] = struct{}{}
+		}
+	}
+}
+
+// Normalize, Türkçe küçük harfe çevirir ve aksanları sadeleştirir. Kelimenin başındaki ve
+// sonundaki harf dışı karakterler önce atılır; leetspeak yalnızca harflerin arasında çözülür,
+// böylece "shit!" gibi noktalama "shiti"ye dönüşmez. Kalan harf dışı karakterler de atılır.
+func Normalize(text string) string {
+	lowered := accentReplacer.Replace(strings.ToLowerSpecial(unicode.TurkishCase, text))
+	trimmed := strings.TrimFunc(lowered, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return r
+		}
+		return -1
+	}, leetReplacer.Replace(trimmed))
+}
+
+// Check, kelimeleri tek tek inceler ve yasaklı olanları aynı uzunlukta yıldızla maskeler.
+// "s i k" gibi harf harf ayrılmış yazımlar birleştirilerek kontrol edilir. Sadeleştirilmiş
+// biçim yalnızca eşleştirmede kullanılır; maskelenmeyen kısımlar ve boşluklar aynen kalır.
+func (f *Filter) Check(text string) Result {
+	spans := fieldSpans(text)
+	if len(spans) == 0 {
+		return Result{Text: text}
+	}
+
+	fields := make([]string, len(spans))
+	normalized := make([]string, len(spans))
+	banned := make([]bool, len(spans))
+	for i, span := range spans {
+		fields[i] = text[span[0]:span[1]]
+		normalized[i] = Normalize(fields[i])
+		banned[i] = f.isBanned(normalized[i])
+	}
+
+	for start := 0; start < len(fields); {
+		end := start
+		var joined strings.Builder
+		for end < len(fields) && utf8.RuneCountInString(normalized[end]) == 1 {
+			joined.WriteString(normalized[end])
+			end++
+		}
+		if end-start > 1 && f.isBanned(joined.String()) {
+			for i := start; i < end; i++ {
+				banned[i] = true
+			}
+		}
+		if end == start {
+			end++
+		}
+		start = end
+	}
+
+	var masked strings.Builder
+	flagged := false
+	last := 0
+	for i, span := range spans {
+		if !banned[i] {
+			continue
+		}
+		masked.WriteString(text[last:span[0]])
+		masked.WriteString(strings.Repeat("*", utf8.RuneCountInString(fields[i])))
+		last = span[1]
+		flagged = true
+	}
+
+	if !flagged {
+		return Result{Text: text}
+	}
+	masked.WriteString(text[last:])
+	return Result{Text: masked.String(), Flagged: true}
+}
+
+// fieldSpans, strings.Fields ile aynı kelimelerin metindeki bayt aralıklarını döndürür.
+func fieldSpans(text string) [][2]int {
+	var spans [][2]int
+	start := -1
+	for i, r := range text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				spans = append(spans, [2]int{start, i})
+				start = -1
+			}
+			continue
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(text)})
+	}
+	return spans
+}
+
+func (f *Filter) isBanned(word string) bool {
+	if word == "" {
+		return false
+	}
+	if _, ok := f.words[word]; ok {
+		return true
+	}
+	_, ok := f.collapsed[collapseRepeats(word)]
+	return ok
+}
+
+func collapseRepeats(word string) string {
+	var builder strings.Builder
+	var last rune
+	for i, r := range word {
+		if i > 0 && r == last {
+			continue
+		}
+		builder.WriteRune(r)
+		last = r
+	}
+	return builder.String()
+}
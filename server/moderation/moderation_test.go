@@ -0,0 +1,52 @@
+package moderation
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"shit!", "shit"},
+		{"fuck!!!", "fuck"},
+		{"|shit|", "shit"},
+		{"(shit)", "shit"},
+		{"sh!t", "shit"},
+		{"s|k", "sik"},
+		{"f.u.c.k", "fuck"},
+		{"ŞEREFSİZ", "serefsiz"},
+		{"pic", "pic"},
+	}
+
+	for _, tt := range tests {
+		if got := Normalize(tt.in); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	filter := &Filter{words: map[string]struct{}{}, collapsed: map[string]struct{}{}}
+	filter.addList(englishWords)
+	filter.addList(turkishWords)
+
+	tests := []struct {
+		in      string
+		want    string
+		flagged bool
+	}{
+		{"shit!", "*****", true},
+		{"what  the fuck!!!", "what  the *******", true},
+		{"sh!t happens", "**** happens", true},
+		{"s i k", "* * *", true},
+		{"nice pic!", "nice pic!", false},
+		{"merhaba dünya", "merhaba dünya", false},
+	}
+
+	for _, tt := range tests {
+		got := filter.Check(tt.in)
+		if got.Text != tt.want || got.Flagged != tt.flagged {
+			t.Errorf("Check(%q) = %q/%v, want %q/%v", tt.in, got.Text, got.Flagged, tt.want, tt.flagged)
+		}
+	}
+}
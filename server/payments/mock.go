@@ -0,0 +1,187 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+
+	"donation-app/server/models"
+)
+
+type MockOutcome string
+
+const (
+	MockSucceed MockOutcome = "succeed"
+	MockFail    MockOutcome = "fail"
+	MockTimeout MockOutcome = "timeout"
+)
+
+var ErrUnknownSession = errors.New("unknown mock checkout session")
+
+type MockSession struct {
+	ID          string
+	OrderID     string
+	Amount      models.Money
+	Description string
+	BuyerName   string
+	SuccessURL  string
+	FailURL     string
+}
+
+// Mock, gerçek bir ağ geçidi gibi davranan, yerel ödeme sayfası sunan geliştirme sağlayıcısıdır.
+type Mock struct {
+	secret  string
+	baseURL string
+
+	mu       sync.Mutex
+	sessions map[string]MockSession
+	refunds  map[string]int64
+}
+
+func newMockFromEnv() (Provider, error) {
+	secret := strings.TrimSpace(os.Getenv("MOCK_PAYMENT_SECRET"))
+	if secret == "" {
+		return nil, ErrNotConfigured
+	}
+
+	return &Mock{
+		secret:   secret,
+		baseURL:  strings.TrimRight(envOrDefault("API_URL", "http://localhost:8080"), "/"),
+		sessions: make(map[string]MockSession),
+		refunds:  make(map[string]int64),
+	}, nil
+}
+
+func (m *Mock) Name() string {
+	return "mock"
+}
+
+func (m *Mock) CreateCheckout(ctx context.Context, req CheckoutRequest) (CheckoutSession, error) {
+	session := MockSession{
+		ID:          uuid.NewString(),
+		OrderID:     req.OrderID,
+		Amount:      req.Amount,
+		Description: req.Description,
+		BuyerName:   req.Buyer.Name,
+		SuccessURL:  req.SuccessURL,
+		FailURL:     req.FailURL,
+	}
+
+	m.mu.Lock()
+	m.sessions[session.ID] = session
+	m.mu.Unlock()
+
+	return CheckoutSession{
+		Provider:    m.Name(),
+		SessionID:   session.ID,
+		RedirectURL: m.baseURL + "/api/payments/mock/checkout/" + session.ID,
+	}, nil
+}
+
+func (m *Mock) Session(id string) (MockSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[id]
+	return session, ok
+}
+
+func (m *Mock) CallbackURL() string {
+	return m.baseURL + "/api/payments/mock/callback"
+}
+
+// CallbackForm, seçilen sonuç için gerçek sağlayıcının göndereceği imzalı bildirimi üretir;
+// oturum CompleteSession çağrılana kadar açık kalır.
+func (m *Mock) CallbackForm(sessionID string, outcome MockOutcome) (url.Values, error) {
+	session, ok := m.Session(sessionID)
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+
+	status := "success"
+	if outcome != MockSucceed {
+		status = "failed"
+	}
+
+	form := url.Values{}
+	form.Set("order_id", session.OrderID)
+	form.Set("status", status)
+	form.Set("amount", strconv.FormatInt(session.Amount.Minor, 10))
+	form.Set("currency", session.Amount.Currency)
+	form.Set("reference", "mock-"+session.ID)
+	if status == "failed" {
+		form.Set("failure_reason", "mock payment declined")
+	}
+	form.Set("hash", m.sign(form))
+
+	return form, nil
+}
+
+// CompleteSession, bildirim onaylandıktan sonra oturumu kapatır. Teslim edilemeyen bildirimler
+// gerçek sağlayıcılardaki gibi yeniden gönderilebilsin diye oturum o zamana kadar tutulur.
+func (m *Mock) CompleteSession(sessionID string) {
+	m.mu.Lock()
+	delete(m.sessions, sessionID)
+	m.mu.Unlock()
+}
+
+func (m *Mock) VerifyCallback(form url.Values) (CallbackResult, error) {
+	if !hmac.Equal([]byte(m.sign(form)), []byte(form.Get("hash"))) {
+		return CallbackResult{}, ErrInvalidSignature
+	}
+
+	minor, err := strconv.ParseInt(form.Get("amount"), 10, 64)
+	if err != nil {
+		return CallbackResult{}, models.ErrInvalidAmount
+	}
+
+	result := CallbackResult{
+		OrderID:   form.Get("order_id"),
+		Status:    CallbackFailed,
+		Amount:    models.NewMoney(minor, form.Get("currency")),
+		Reference: form.Get("reference"),
+	}
+	switch form.Get("status") {
+	case "success":
+		result.Status = CallbackSucceeded
+	case "chargeback":
+		result.Status = CallbackChargedBack
+	default:
+		result.FailureReason = form.Get("failure_reason")
+	}
+
+	return result, nil
+}
+
+func (m *Mock) CallbackAck() string {
+	return "OK"
+}
+
+func (m *Mock) Refund(ctx context.Context, req RefundRequest) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.refunds[req.OrderID] += req.Amount.Minor
+	return nil
+}
+
+func (m *Mock) sign(form url.Values) string {
+	payload := strings.Join([]string{
+		form.Get("order_id"),
+		form.Get("status"),
+		form.Get("amount"),
+		form.Get("currency"),
+		form.Get("reference"),
+	}, "|")
+
+	mac := hmac.New(sha256.New, []byte(m.secret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,253 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"donation-app/server/models"
+)
+
+const (
+	payTRTokenURL  = "https://www.paytr.com/odeme/api/get-token"
+	payTRRefundURL = "https://www.paytr.com/odeme/iade"
+	payTRIframeURL = "https://www.paytr.com/odeme/guvenli/"
+)
+
+var payTRCurrencies = map[string]string{
+	"TRY": "TL",
+	"USD": "USD",
+	"EUR": "EUR",
+	"GBP": "GBP",
+}
+
+type payTR struct {
+	merchantID   string
+	merchantKey  string
+	merchantSalt string
+	testMode     string
+	debugOn      string
+	defaultPhone string
+	tokenURL     string
+	refundURL    string
+	client       *http.Client
+}
+
+func newPayTRFromEnv() (Provider, error) {
+	p := &payTR{
+		merchantID:   strings.TrimSpace(os.Getenv("PAYTR_MERCHANT_ID")),
+		merchantKey:  strings.TrimSpace(os.Getenv("PAYTR_MERCHANT_KEY")),
+		merchantSalt: strings.TrimSpace(os.Getenv("PAYTR_MERCHANT_SALT")),
+		testMode:     envOrDefault("PAYTR_TEST_MODE", "1"),
+		debugOn:      envOrDefault("PAYTR_DEBUG_ON", "0"),
+		defaultPhone: envOrDefault("PAYTR_DEFAULT_PHONE", "+905555555555"),
+		tokenURL:     envOrDefault("PAYTR_API_URL", payTRTokenURL),
+		refundURL:    envOrDefault("PAYTR_REFUND_URL", payTRRefundURL),
+		client:       &http.Client{Timeout: 15 * time.Second},
+	}
+
+	if p.merchantID == "" || p.merchantKey == "" || p.merchantSalt == "" {
+		return nil, ErrNotConfigured
+	}
+	return p, nil
+}
+
+func (p *payTR) Name() string {
+	return "paytr"
+}
+
+func (p *payTR) CreateCheckout(ctx context.Context, req CheckoutRequest) (CheckoutSession, error) {
+	currency, ok := payTRCurrencies[req.Amount.Currency]
+	if !ok {
+		return CheckoutSession{}, models.ErrUnsupportedCurrency
+	}
+
+	basket, err := buildPayTRBasket(req.Description, req.Amount)
+	if err != nil {
+		return CheckoutSession{}, err
+	}
+
+	userIP := req.Buyer.IP
+	if userIP == "" {
+		userIP = "127.0.0.1"
+	}
+	phone := req.Buyer.Phone
+	if phone == "" {
+		phone = p.defaultPhone
+	}
+	address := req.Buyer.Address
+	if address == "" {
+		address = "Türkiye"
+	}
+
+	paymentAmount := strconv.FormatInt(req.Amount.Minor, 10)
+	noInstallment := "1"
+	maxInstallment := "0"
+
+	hashStr := p.merchantID + userIP + req.OrderID + req.Buyer.Email + paymentAmount + basket + noInstallment + maxInstallment + currency + p.testMode
+	token := p.sign(hashStr + p.merchantSalt)
+
+	form := url.Values{}
+	form.Set("merchant_id", p.merchantID)
+	form.Set("user_ip", userIP)
+	form.Set("merchant_oid", req.OrderID)
+	form.Set("email", req.Buyer.Email)
+	form.Set("payment_amount", paymentAmount)
+	form.Set("paytr_token", token)
+	form.Set("user_basket", basket)
+	form.Set("debug_on", p.debugOn)
+	form.Set("no_installment", noInstallment)
+	form.Set("max_installment", maxInstallment)
+	form.Set("user_name", req.Buyer.Name)
+	form.Set("user_address", address)
+	form.Set("user_phone", phone)
+	form.Set("merchant_ok_url", req.SuccessURL)
+	form.Set("merchant_fail_url", req.FailURL)
+	form.Set("timeout_limit", "30")
+	form.Set("currency", currency)
+	form.Set("test_mode", p.testMode)
+	form.Set("lang", "tr")
+
+	var result struct {
+		Status string `json:"status"`
+		Token  string `json:"token"`
+		Reason string `json:"reason"`
+	}
+	if err := p.post(ctx, p.tokenURL, form, &result); err != nil {
+		return CheckoutSession{}, err
+	}
+
+	if strings.ToLower(result.Status) != "success" {
+		return CheckoutSession{}, fmt.Errorf("paytr get-token failed: %s", result.Reason)
+	}
+
+	return CheckoutSession{
+		Provider:    p.Name(),
+		SessionID:   result.Token,
+		RedirectURL: payTRIframeURL + result.Token,
+	}, nil
+}
+
+func (p *payTR) VerifyCallback(form url.Values) (CallbackResult, error) {
+	orderID := form.Get("merchant_oid")
+	status := form.Get("status")
+	totalAmount := form.Get("total_amount")
+
+	expected := p.sign(orderID + p.merchantSalt + status + totalAmount)
+	if !hmac.Equal([]byte(expected), []byte(form.Get("hash"))) {
+		return CallbackResult{}, ErrInvalidSignature
+	}
+
+	currency := models.DefaultCurrency
+	for code, payTRCode := range payTRCurrencies {
+		if payTRCode == form.Get("currency") {
+			currency = code
+		}
+	}
+
+	amountField := form.Get("payment_amount")
+	if amountField == "" {
+		amountField = totalAmount
+	}
+	minor, err := strconv.ParseInt(amountField, 10, 64)
+	if err != nil {
+		return CallbackResult{}, models.ErrInvalidAmount
+	}
+
+	result := CallbackResult{
+		OrderID:   orderID,
+		Status:    CallbackFailed,
+		Amount:    models.NewMoney(minor, currency),
+		Reference: orderID,
+	}
+	// PayTR ters ibrazları bu bildirimle göndermez; mağaza panelinden takip edilip yönetici
+	// uç noktasıyla kaydedilir.
+	if status == "success" {
+		result.Status = CallbackSucceeded
+	} else {
+		result.FailureReason = strings.TrimSpace(form.Get("failed_reason_code") + " " + form.Get("failed_reason_msg"))
+	}
+
+	return result, nil
+}
+
+func (p *payTR) CallbackAck() string {
+	return "OK"
+}
+
+func (p *payTR) Refund(ctx context.Context, req RefundRequest) error {
+	returnAmount := req.Amount.Decimal()
+
+	form := url.Values{}
+	form.Set("merchant_id", p.merchantID)
+	form.Set("merchant_oid", req.OrderID)
+	form.Set("return_amount", returnAmount)
+	form.Set("paytr_token", p.sign(p.merchantID+req.OrderID+returnAmount+p.merchantSalt))
+	if req.Reference != "" {
+		form.Set("reference_no", req.Reference)
+	}
+
+	var result struct {
+		Status string `json:"status"`
+		ErrNo  string `json:"err_no"`
+		ErrMsg string `json:"err_msg"`
+	}
+	if err := p.post(ctx, p.refundURL, form, &result); err != nil {
+		return err
+	}
+
+	if strings.ToLower(result.Status) != "success" {
+		return fmt.Errorf("paytr refund failed: %s %s", result.ErrNo, result.ErrMsg)
+	}
+	return nil
+}
+
+func (p *payTR) sign(payload string) string {
+	mac := hmac.New(sha256.New, []byte(p.merchantKey))
+	mac.Write([]byte(payload))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (p *payTR) post(ctx context.Context, endpoint string, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func buildPayTRBasket(description string, amount models.Money) (string, error) {
+	basket := [][]string{{description, amount.Decimal(), "1"}}
+
+	jsonBytes, err := json.Marshal(basket)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(jsonBytes), nil
+}
+
+func envOrDefault(key string, fallback string) string {
+	value := strings.TrimSpace(os.Getenv(key))
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,118 @@
+package payments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"donation-app/server/models"
+)
+
+var (
+	ErrInvalidSignature = errors.New("payment callback signature mismatch")
+	ErrNotConfigured    = errors.New("payment provider is not configured")
+)
+
+type Buyer struct {
+	Name    string
+	Email   string
+	Phone   string
+	Address string
+	IP      string
+}
+
+type CheckoutRequest struct {
+	OrderID     string
+	Amount      models.Money
+	Description string
+	Buyer       Buyer
+	SuccessURL  string
+	FailURL     string
+}
+
+type CheckoutSession struct {
+	Provider    string `json:"provider"`
+	SessionID   string `json:"sessionId"`
+	RedirectURL string `json:"redirectUrl"`
+}
+
+type CallbackStatus string
+
+const (
+	CallbackSucceeded CallbackStatus = "succeeded"
+	CallbackFailed    CallbackStatus = "failed"
+	// CallbackChargedBack, ödenmiş bir işlem için kart sahibinin itirazı kabul edildiğinde gelir.
+	CallbackChargedBack CallbackStatus = "charged_back"
+)
+
+type CallbackResult struct {
+	OrderID       string
+	Status        CallbackStatus
+	Amount        models.Money
+	Reference     string
+	FailureReason string
+}
+
+type RefundRequest struct {
+	OrderID   string
+	Amount    models.Money
+	Reference string
+}
+
+type Provider interface {
+	Name() string
+	CreateCheckout(ctx context.Context, req CheckoutRequest) (CheckoutSession, error)
+	VerifyCallback(form url.Values) (CallbackResult, error)
+	// CallbackAck, sağlayıcının bildirimi başarılı saymak için beklediği yanıt gövdesidir.
+	CallbackAck() string
+	Refund(ctx context.Context, req RefundRequest) error
+}
+
+var factories = map[string]func() (Provider, error){
+	"paytr": newPayTRFromEnv,
+	"mock":  newMockFromEnv,
+}
+
+var active Provider
+
+func Setup(name string) error {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = "paytr"
+	}
+
+	factory, ok := factories[name]
+	if !ok {
+		return fmt.Errorf("unknown payment provider %q", name)
+	}
+	// Sahte sağlayıcı gerçek para çekmeden bağışı ödenmiş sayar; canlıda açılamaz.
+	if name == "mock" && isProduction() {
+		return errors.New(`payment provider "mock" is not allowed with APP_ENV=production`)
+	}
+
+	provider, err := factory()
+	if err != nil {
+		return err
+	}
+
+	active = provider
+	return nil
+}
+
+func isProduction() bool {
+	return strings.EqualFold(strings.TrimSpace(os.Getenv("APP_ENV")), "production")
+}
+
+func Active() Provider {
+	return active
+}
+
+func Get(name string) (Provider, bool) {
+	if active == nil || active.Name() != strings.ToLower(name) {
+		return nil, false
+	}
+	return active, true
+}
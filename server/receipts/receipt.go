@@ -0,0 +1,211 @@
+package receipts
+
+import (
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"donation-app/server/models"
+)
+
+// Çekirdek PDF yazı tipleri ş, ğ, ı gibi harfleri içermediği için DejaVu gömülür.
+var (
+	//go:embed fonts/DejaVuSansCondensed.ttf
+	regularFont []byte
+	//go:embed fonts/DejaVuSansCondensed-Bold.ttf
+	boldFont []byte
+)
+
+const fontFamily = "DejaVu"
+
+// Turkey, 2016'dan beri sabit UTC+3 kullanır; konteynerlerde tzdata bulunmayabileceği
+// için bölge adıyla yüklenmez.
+var Turkey = time.FixedZone("TRT", 3*60*60)
+
+type Platform struct {
+	Name    string
+	Address string
+	TaxID   string
+	Email   string
+}
+
+// PlatformFromEnv, makbuzun başlığındaki platform bilgilerini ortam değişkenlerinden okur.
+func PlatformFromEnv() Platform {
+	return Platform{
+		Name:    envOrDefault("PLATFORM_NAME", "Donate"),
+		Address: strings.TrimSpace(os.Getenv("PLATFORM_ADDRESS")),
+		TaxID:   strings.TrimSpace(os.Getenv("PLATFORM_TAX_ID")),
+		Email:   strings.TrimSpace(os.Getenv("PLATFORM_EMAIL")),
+	}
+}
+
+type Party struct {
+	Name     string
+	Username string
+	Email    string
+}
+
+type Receipt struct {
+	Number       string
+	IssuedAt     time.Time
+	Platform     Platform
+	Donor        Party
+	Recipient    Party
+	DonationID   string
+	PaymentRef   string
+	Provider     string
+	PaidAt       time.Time
+	Status       models.DonationStatus
+	Gross        models.Money
+	PlatformFee  models.Money
+	ProcessorFee models.Money
+	Net          models.Money
+}
+
+// Render, makbuzu tek sayfalık A4 PDF olarak yazar.
+func Render(w io.Writer, receipt Receipt) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", regularFont)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", boldFont)
+	pdf.SetTitle("Bağış Makbuzu "+receipt.Number, true)
+	pdf.SetCreator(receipt.Platform.Name, true)
+	pdf.SetCreationDate(receipt.IssuedAt)
+	pdf.SetModificationDate(receipt.IssuedAt)
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AddPage()
+
+	pdf.SetFont(fontFamily, "B", 18)
+	pdf.CellFormat(0, 10, receipt.Platform.Name, "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 9)
+	pdf.SetTextColor(90, 90, 90)
+	for _, line := range platformLines(receipt.Platform) {
+		pdf.CellFormat(0, 5, line, "", 1, "L", false, 0, "")
+	}
+	pdf.SetTextColor(0, 0, 0)
+	pdf.Ln(6)
+
+	pdf.SetFont(fontFamily, "B", 14)
+	pdf.CellFormat(0, 8, "Bağış Makbuzu", "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	field(pdf, "Makbuz No", receipt.Number)
+	field(pdf, "Düzenlenme Tarihi", formatDate(receipt.IssuedAt))
+	if receipt.Status != models.DonationPaid {
+		pdf.SetTextColor(180, 30, 30)
+		field(pdf, "Durum", statusLabel(receipt.Status))
+		pdf.SetTextColor(0, 0, 0)
+	}
+	pdf.Ln(4)
+
+	section(pdf, "Bağışçı")
+	field(pdf, "Ad", receipt.Donor.Name)
+	if receipt.Donor.Username != "" {
+		field(pdf, "Kullanıcı Adı", "@"+receipt.Donor.Username)
+	}
+	if receipt.Donor.Email != "" {
+		field(pdf, "E-posta", receipt.Donor.Email)
+	}
+	pdf.Ln(4)
+
+	section(pdf, "Alıcı")
+	field(pdf, "Ad", receipt.Recipient.Name)
+	field(pdf, "Kullanıcı Adı", "@"+receipt.Recipient.Username)
+	pdf.Ln(4)
+
+	section(pdf, "Ödeme")
+	field(pdf, "Bağış No", receipt.DonationID)
+	field(pdf, "Ödeme Tarihi", formatDate(receipt.PaidAt))
+	if receipt.Provider != "" {
+		field(pdf, "Ödeme Kuruluşu", receipt.Provider)
+	}
+	if receipt.PaymentRef != "" {
+		field(pdf, "Ödeme Referansı", receipt.PaymentRef)
+	}
+	pdf.Ln(4)
+
+	section(pdf, "Tutarlar")
+	amount(pdf, "Bağış Tutarı (brüt)", receipt.Gross, false)
+	amount(pdf, "Platform Komisyonu", receipt.PlatformFee, false)
+	amount(pdf, "Ödeme Kuruluşu Komisyonu", receipt.ProcessorFee, false)
+	amount(pdf, "Alıcıya Geçen Net Tutar", receipt.Net, true)
+	pdf.Ln(10)
+
+	pdf.SetFont(fontFamily, "", 8)
+	pdf.SetTextColor(110, 110, 110)
+	pdf.MultiCell(0, 4, "Bu makbuz "+receipt.Platform.Name+" tarafından elektronik ortamda düzenlenmiştir ve imza gerektirmez. "+
+		"Makbuz numarası her takvim yılında 1'den başlayarak sırayla verilir.", "", "L", false)
+
+	if err := pdf.Error(); err != nil {
+		return err
+	}
+	return pdf.Output(w)
+}
+
+func section(pdf *gofpdf.Fpdf, title string) {
+	pdf.SetFont(fontFamily, "B", 11)
+	pdf.CellFormat(0, 7, title, "B", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 10)
+	pdf.Ln(1)
+}
+
+func field(pdf *gofpdf.Fpdf, label, value string) {
+	pdf.CellFormat(55, 6, label, "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, value, "", 1, "L", false, 0, "")
+}
+
+func amount(pdf *gofpdf.Fpdf, label string, value models.Money, bold bool) {
+	if bold {
+		pdf.SetFont(fontFamily, "B", 10)
+	}
+	pdf.CellFormat(110, 6, label, "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 6, value.Decimal()+" "+value.Currency, "", 1, "R", false, 0, "")
+	if bold {
+		pdf.SetFont(fontFamily, "", 10)
+	}
+}
+
+func platformLines(platform Platform) []string {
+	lines := make([]string, 0, 3)
+	if platform.Address != "" {
+		lines = append(lines, platform.Address)
+	}
+	if platform.TaxID != "" {
+		lines = append(lines, "Vergi No: "+platform.TaxID)
+	}
+	if platform.Email != "" {
+		lines = append(lines, platform.Email)
+	}
+	return lines
+}
+
+func formatDate(t time.Time) string {
+	return t.In(Turkey).Format("02.01.2006 15:04")
+}
+
+func statusLabel(status models.DonationStatus) string {
+	switch status {
+	case models.DonationRefunded:
+		return "İade edildi"
+	case models.DonationChargedBack:
+		return "Ters ibraz edildi"
+	default:
+		return string(status)
+	}
+}
+
+// FormatNumber, makbuz numarasını "2026-000042" biçiminde döndürür.
+func FormatNumber(year int, sequence int64) string {
+	return fmt.Sprintf("%d-%06d", year, sequence)
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return fallback
+}
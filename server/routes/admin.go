@@ -0,0 +1,19 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"donation-app/server/middleware"
+)
+
+func RegisterAdminRoutes(router fiber.Router) {
+	admin := router.Group("", middleware.Protected(), middleware.AdminOnly())
+	admin.Get("/users/:username/wallet-audit", adminWalletAuditHandler)
+	admin.Post("/users/:username/wallet-reconcile", adminReconcileWalletHandler)
+	admin.Get("/donations/review", adminReviewDonationsHandler)
+	admin.Get("/donations/:id", adminDonationHandler)
+	admin.Post("/donations/:id/chargeback", chargebackDonationHandler)
+	admin.Get("/payouts", adminListPayoutsHandler)
+	admin.Post("/payouts/:id/approve", adminApprovePayoutHandler)
+	admin.Post("/payouts/:id/reject", adminRejectPayoutHandler)
+}
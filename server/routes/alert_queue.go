@@ -0,0 +1,345 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/events"
+	"donation-app/server/models"
+)
+
+const alertQueueEventType = "queue"
+
+var (
+	errAlertNotFound = errors.New("alert not found")
+	errAlertNotHeld  = errors.New("alert is not held")
+	errAlertHeld     = errors.New("alert message is awaiting review")
+)
+
+// alertQueueState, kuyruğun istemcilere gönderilen hâlidir. Overlay yalnızca Paused ve Current
+// alanlarını kullanır; bekleyen ve onay bekleyen listeler panel içindir.
+type alertQueueState struct {
+	Paused           bool           `json:"paused"`
+	Current          *overlayAlert  `json:"current"`
+	CurrentStartedAt *time.Time     `json:"currentStartedAt,omitempty"`
+	Pending          []overlayAlert `json:"pending"`
+	Held             []overlayAlert `json:"held"`
+}
+
+func (s alertQueueState) forOverlay() alertQueueState {
+	return alertQueueState{Paused: s.Paused, Current: s.Current, CurrentStartedAt: s.CurrentStartedAt}
+}
+
+// holdMessageForReview, filtrelenmiş mesajları ya da yaratıcı tüm mesajları onaydan geçirmek
+// istiyorsa mesajlı bağışları onaya bekletir. Bağış overlay'e yayınlanmadan önce çağrılmalıdır;
+// bekletilen mesaj onaylanana kadar hiçbir overlay yükünde yer almaz.
+func holdMessageForReview(ctx context.Context, donation models.Donation) (models.Donation, error) {
+	if donation.MessageReview != "" {
+		return donation, nil
+	}
+
+	held := donation.MessageFiltered
+	if !held && donation.Message != "" {
+		var recipient models.User
+		err := database.Collection("users").FindOne(ctx, bson.M{"_id": donation.ToUserID},
+			options.FindOne().SetProjection(bson.M{"messageSettings": 1}),
+		).Decode(&recipient)
+		if err != nil {
+			return donation, err
+		}
+		held = recipient.MessageSettings.HoldMessages
+	}
+	if !held {
+		return donation, nil
+	}
+
+	donation.MessageReview = models.MessageReviewHeld
+	_, err := database.Collection("donations").UpdateOne(ctx,
+		bson.M{"_id": donation.ID, "messageReview": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"messageReview": models.MessageReviewHeld}},
+	)
+	return donation, err
+}
+
+// enqueueAlert, ödenen bağışı kuyruğa ekler; mesajı onay bekleyen bağışlar ayrı listede tutulur.
+func enqueueAlert(ctx context.Context, donation models.Donation) error {
+	return mutateAlertQueue(ctx, donation.ToUserID, func(queue *models.AlertQueue) error {
+		if queueContains(queue, donation.ID) {
+			return nil
+		}
+		if donation.MessageReview == models.MessageReviewHeld {
+			queue.Held = append(queue.Held, donation.ID)
+		} else {
+			queue.Pending = append(queue.Pending, donation.ID)
+		}
+		return nil
+	})
+}
+
+func pauseAlertQueue(ctx context.Context, creatorID primitive.ObjectID, paused bool) error {
+	return mutateAlertQueue(ctx, creatorID, func(queue *models.AlertQueue) error {
+		queue.Paused = paused
+		return nil
+	})
+}
+
+// skipAlert, oynatılan uyarıyı bırakır. expected boş değilse yalnızca o uyarı oynatılıyorsa
+// ilerler; aynı anahtarla açık birden fazla overlay aynı bitişi iki kez saymaz.
+func skipAlert(ctx context.Context, creatorID primitive.ObjectID, expected *primitive.ObjectID) error {
+	return mutateAlertQueue(ctx, creatorID, func(queue *models.AlertQueue) error {
+		if queue.Current == nil {
+			return nil
+		}
+		if expected != nil && *queue.Current != *expected {
+			return nil
+		}
+		queue.Current = nil
+		queue.CurrentStartedAt = nil
+		return nil
+	})
+}
+
+// replayAlert, daha önce ödenmiş bir bağışı kuyruğun başına ekler. Mesajı onay bekleyen
+// bağışlar önce onaylanmalıdır; aksi hâlde incelenmemiş mesaj yayına çıkardı.
+func replayAlert(ctx context.Context, creatorID, donationID primitive.ObjectID) error {
+	var donation models.Donation
+	err := database.Collection("donations").FindOne(ctx, bson.M{
+		"_id":      donationID,
+		"toUserId": creatorID,
+		"status":   models.DonationPaid,
+	}, options.FindOne().SetProjection(bson.M{"messageReview": 1})).Decode(&donation)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return errAlertNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if donation.MessageReview == models.MessageReviewHeld {
+		return errAlertHeld
+	}
+
+	return mutateAlertQueue(ctx, creatorID, func(queue *models.AlertQueue) error {
+		queue.Pending = append([]primitive.ObjectID{donationID}, removeObjectID(queue.Pending, donationID)...)
+		return nil
+	})
+}
+
+// approveAlert, bekletilen mesajı onaylar ve bağışı mesajıyla birlikte overlay'e yeniden yayınlar.
+func approveAlert(ctx context.Context, creatorID, donationID primitive.ObjectID) error {
+	if err := reviewHeldMessage(ctx, creatorID, donationID, models.MessageReviewApproved); err != nil {
+		return err
+	}
+
+	var donation models.Donation
+	if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+		return err
+	}
+	events.Publish(creatorID, donationPaidEvent(donation))
+
+	return mutateAlertQueue(ctx, creatorID, func(queue *models.AlertQueue) error {
+		held := removeObjectID(queue.Held, donationID)
+		if len(held) == len(queue.Held) {
+			return nil
+		}
+		queue.Held = held
+		queue.Pending = append(queue.Pending, donationID)
+		return nil
+	})
+}
+
+// rejectAlert, bekletilen mesajı kalıcı olarak gizler; bağış kuyruktan çıkarılır.
+func rejectAlert(ctx context.Context, creatorID, donationID primitive.ObjectID) error {
+	if err := reviewHeldMessage(ctx, creatorID, donationID, models.MessageReviewRejected); err != nil {
+		return err
+	}
+
+	return mutateAlertQueue(ctx, creatorID, func(queue *models.AlertQueue) error {
+		queue.Held = removeObjectID(queue.Held, donationID)
+		return nil
+	})
+}
+
+// reviewHeldMessage, yalnızca hâlâ onay bekleyen mesajın durumunu değiştirir; aynı komutun iki
+// kez gelmesi errAlertNotHeld döndürür.
+func reviewHeldMessage(ctx context.Context, creatorID, donationID primitive.ObjectID, review models.MessageReview) error {
+	update, err := database.Collection("donations").UpdateOne(ctx,
+		bson.M{"_id": donationID, "toUserId": creatorID, "messageReview": models.MessageReviewHeld},
+		bson.M{"$set": bson.M{"messageReview": review}},
+	)
+	if err != nil {
+		return err
+	}
+	if update.MatchedCount == 0 {
+		return errAlertNotHeld
+	}
+	return nil
+}
+
+// removeAlert, iade ya da ters ibraz edilen bağışı kuyruğun her yerinden çıkarır.
+func removeAlert(ctx context.Context, donation models.Donation) error {
+	return mutateAlertQueue(ctx, donation.ToUserID, func(queue *models.AlertQueue) error {
+		if !queueContains(queue, donation.ID) {
+			return nil
+		}
+		queue.Pending = removeObjectID(queue.Pending, donation.ID)
+		queue.Held = removeObjectID(queue.Held, donation.ID)
+		if queue.Current != nil && *queue.Current == donation.ID {
+			queue.Current = nil
+			queue.CurrentStartedAt = nil
+		}
+		return nil
+	})
+}
+
+// mutateAlertQueue, kuyruğu işlem içinde okuyup değiştirir, duraklatılmamışsa sıradaki uyarıyı
+// oynatmaya alır ve işlem kaydedildikten sonra yeni durumu yaratıcının abonelerine yayınlar.
+func mutateAlertQueue(ctx context.Context, creatorID primitive.ObjectID, mutate func(queue *models.AlertQueue) error) error {
+	var queue models.AlertQueue
+	err := database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var err error
+		queue, err = loadAlertQueue(sessCtx, creatorID)
+		if err != nil {
+			return err
+		}
+		if err := mutate(&queue); err != nil {
+			return err
+		}
+
+		if !queue.Paused && queue.Current == nil && len(queue.Pending) > 0 {
+			next := queue.Pending[0]
+			now := time.Now().UTC()
+			queue.Current = &next
+			queue.CurrentStartedAt = &now
+			queue.Pending = queue.Pending[1:]
+		}
+		queue.UpdatedAt = time.Now().UTC()
+
+		_, err = database.Collection("alert_queues").ReplaceOne(sessCtx,
+			bson.M{"_id": creatorID}, queue, options.Replace().SetUpsert(true),
+		)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	publishAlertQueue(ctx, queue)
+	return nil
+}
+
+func loadAlertQueue(ctx context.Context, creatorID primitive.ObjectID) (models.AlertQueue, error) {
+	queue := models.AlertQueue{ID: creatorID}
+	err := database.Collection("alert_queues").FindOne(ctx, bson.M{"_id": creatorID}).Decode(&queue)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return queue, err
+	}
+	if queue.Pending == nil {
+		queue.Pending = []primitive.ObjectID{}
+	}
+	if queue.Held == nil {
+		queue.Held = []primitive.ObjectID{}
+	}
+	return queue, nil
+}
+
+func publishAlertQueue(ctx context.Context, queue models.AlertQueue) {
+	state, err := buildAlertQueueState(ctx, queue)
+	if err != nil {
+		log.Printf("alert queue state error for %s: %v", queue.ID.Hex(), err)
+		return
+	}
+	events.Publish(queue.ID, events.Event{ID: queue.UpdatedAt.Format(time.RFC3339Nano), Type: alertQueueEventType, Data: state})
+}
+
+// buildAlertQueueState, kuyruktaki kimlikleri tek sorguyla uyarı içeriklerine çevirir.
+func buildAlertQueueState(ctx context.Context, queue models.AlertQueue) (alertQueueState, error) {
+	ids := make([]primitive.ObjectID, 0, len(queue.Pending)+len(queue.Held)+1)
+	if queue.Current != nil {
+		ids = append(ids, *queue.Current)
+	}
+	ids = append(ids, queue.Pending...)
+	ids = append(ids, queue.Held...)
+
+	state := alertQueueState{
+		Paused:           queue.Paused,
+		CurrentStartedAt: queue.CurrentStartedAt,
+		Pending:          []overlayAlert{},
+		Held:             []overlayAlert{},
+	}
+	if len(ids) == 0 {
+		return state, nil
+	}
+
+	cursor, err := database.Collection("donations").Find(ctx, bson.M{"_id": bson.M{"$in": ids}, "toUserId": queue.ID})
+	if err != nil {
+		return state, err
+	}
+	defer cursor.Close(ctx)
+
+	var donations []models.Donation
+	if err := cursor.All(ctx, &donations); err != nil {
+		return state, err
+	}
+
+	alerts := make(map[primitive.ObjectID]overlayAlert, len(donations))
+	for _, donation := range donations {
+		alerts[donation.ID] = newOverlayAlert(donation)
+	}
+
+	if queue.Current != nil {
+		if alert, ok := alerts[*queue.Current]; ok {
+			state.Current = &alert
+		}
+	}
+	for _, id := range queue.Pending {
+		if alert, ok := alerts[id]; ok {
+			state.Pending = append(state.Pending, alert)
+		}
+	}
+	for _, donation := range donations {
+		if donation.MessageReview == models.MessageReviewHeld {
+			alerts[donation.ID] = reviewAlert(donation)
+		}
+	}
+	for _, id := range queue.Held {
+		if alert, ok := alerts[id]; ok {
+			state.Held = append(state.Held, alert)
+		}
+	}
+	return state, nil
+}
+
+func queueContains(queue *models.AlertQueue, id primitive.ObjectID) bool {
+	if queue.Current != nil && *queue.Current == id {
+		return true
+	}
+	for _, pending := range queue.Pending {
+		if pending == id {
+			return true
+		}
+	}
+	for _, held := range queue.Held {
+		if held == id {
+			return true
+		}
+	}
+	return false
+}
+
+func removeObjectID(ids []primitive.ObjectID, target primitive.ObjectID) []primitive.ObjectID {
+	result := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if id != target {
+			result = append(result, id)
+		}
+	}
+	return result
+}
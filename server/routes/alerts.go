@@ -0,0 +1,308 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"donation-app/server/database"
+	"donation-app/server/events"
+	"donation-app/server/middleware"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	alertTicketTTL      = time.Minute
+	alertSocketPing     = 25 * time.Second
+	alertSocketReadWait = 60 * time.Second
+	alertSocketMaxFrame = 4096
+)
+
+const (
+	alertRoleOverlay    = "overlay"
+	alertRoleController = "controller"
+)
+
+// Panelden gelen komutlar; overlay yalnızca oynattığı uyarının bittiğini bildirebilir.
+const (
+	alertCommandPause   = "pause"
+	alertCommandResume  = "resume"
+	alertCommandSkip    = "skip"
+	alertCommandReplay  = "replay"
+	alertCommandApprove = "approve"
+	alertCommandReject  = "reject"
+	alertCommandEnded   = "ended"
+)
+
+type alertCommand struct {
+	Type       string `json:"type"`
+	DonationID string `json:"donationId"`
+}
+
+type alertSocketMessage struct {
+	Type    string      `json:"type"`
+	Data    interface{} `json:"data,omitempty"`
+	Command string      `json:"command,omitempty"`
+	Message string      `json:"message,omitempty"`
+}
+
+func RegisterAlertRoutes(router fiber.Router) {
+	router.Post("/ticket", middleware.Protected(), createAlertTicketHandler)
+	router.Get("/queue", middleware.Protected(), alertQueueHandler)
+	router.Get("/ws", alertSocketAuth, websocket.New(alertSocketHandler))
+}
+
+// createAlertTicketHandler, panelin WebSocket bağlantısı için tek kullanımlık bilet üretir.
+func createAlertTicketHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ticket, err := utils.RandomToken(32)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağlantı bileti oluşturulamadı")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	expiresAt := time.Now().UTC().Add(alertTicketTTL)
+	record := models.AlertTicket{UserID: user.ID, TicketHash: utils.HashToken(ticket), ExpiresAt: expiresAt}
+	if _, err := database.Collection("alert_tickets").InsertOne(ctx, record); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağlantı bileti oluşturulamadı")
+	}
+
+	return utils.Success(c, fiber.StatusCreated, fiber.Map{"ticket": ticket, "expiresAt": expiresAt})
+}
+
+func alertQueueHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	queue, err := loadAlertQueue(ctx, user.ID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Uyarı kuyruğu yüklenemedi")
+	}
+	state, err := buildAlertQueueState(ctx, queue)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Uyarı kuyruğu yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"queue": state})
+}
+
+// alertSocketAuth, panel biletini ya da overlay anahtarını el sıkışmadan önce doğrular ve
+// bağlantının rolünü belirler.
+func alertSocketAuth(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return utils.Error(c, fiber.StatusUpgradeRequired, "WebSocket bağlantısı gerekli")
+	}
+
+	ticket := c.Query("ticket")
+	if ticket == "" {
+		return middleware.OverlayKey(models.OverlayScopeAlerts)(c)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var record models.AlertTicket
+	err := database.Collection("alert_tickets").FindOneAndDelete(ctx, bson.M{
+		"ticketHash": utils.HashToken(ticket),
+		"expiresAt":  bson.M{"$gt": time.Now().UTC()},
+	}).Decode(&record)
+	if err != nil {
+		return utils.Error(c, fiber.StatusUnauthorized, "Geçersiz ya da süresi dolmuş bilet")
+	}
+
+	c.Locals("alertCreatorId", record.UserID)
+	return c.Next()
+}
+
+func alertSocketHandler(conn *websocket.Conn) {
+	creatorID, role := alertSocketIdentity(conn)
+	overlayKey, hasOverlayKey := conn.Locals("overlayKey").(models.OverlayKey)
+	if creatorID.IsZero() {
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "unauthorized"))
+		return
+	}
+
+	subscription, unsubscribe := events.Subscribe(creatorID)
+	defer unsubscribe()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	queue, err := loadAlertQueue(ctx, creatorID)
+	var state alertQueueState
+	if err == nil {
+		state, err = buildAlertQueueState(ctx, queue)
+	}
+	cancel()
+	if err != nil {
+		log.Printf("alert socket state error for %s: %v", creatorID.Hex(), err)
+		_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "queue unavailable"))
+		return
+	}
+
+	replies := make(chan alertSocketMessage, 8)
+	done := make(chan struct{})
+
+	conn.SetReadLimit(alertSocketMaxFrame)
+	_ = conn.SetReadDeadline(time.Now().Add(alertSocketReadWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(alertSocketReadWait))
+	})
+
+	go func() {
+		defer close(done)
+		for {
+			var command alertCommand
+			if err := conn.ReadJSON(&command); err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					log.Printf("alert socket read error for %s: %v", creatorID.Hex(), err)
+				}
+				return
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(alertSocketReadWait))
+
+			if reply, ok := handleAlertCommand(creatorID, role, command); ok {
+				select {
+				case replies <- reply:
+				default:
+				}
+			}
+		}
+	}()
+
+	// Yazma işlemleri yalnızca bu döngüden yapılır; bağlantı eşzamanlı yazmayı desteklemez.
+	if err := conn.WriteJSON(alertSocketMessage{Type: alertQueueEventType, Data: alertStateForRole(state, role)}); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(alertSocketPing)
+	defer ping.Stop()
+
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case event, ok := <-subscription:
+			if !ok {
+				return
+			}
+			if hasOverlayKey && isOverlayKeyRevokedEvent(event, overlayKey.ID) {
+				closeRevokedAlertSocket(conn)
+				return
+			}
+			if event.Type != alertQueueEventType {
+				continue
+			}
+			state, _ := event.Data.(alertQueueState)
+			err = conn.WriteJSON(alertSocketMessage{Type: alertQueueEventType, Data: alertStateForRole(state, role)})
+		case reply := <-replies:
+			err = conn.WriteJSON(reply)
+		case <-ping.C:
+			if hasOverlayKey && !overlayKeyStillActive(overlayKey) {
+				closeRevokedAlertSocket(conn)
+				return
+			}
+			err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func closeRevokedAlertSocket(conn *websocket.Conn) {
+	_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.ClosePolicyViolation, "overlay key revoked"))
+}
+
+func alertSocketIdentity(conn *websocket.Conn) (primitive.ObjectID, string) {
+	if creatorID, ok := conn.Locals("alertCreatorId").(primitive.ObjectID); ok {
+		return creatorID, alertRoleController
+	}
+	if key, ok := conn.Locals("overlayKey").(models.OverlayKey); ok {
+		return key.UserID, alertRoleOverlay
+	}
+	return primitive.NilObjectID, ""
+}
+
+func alertStateForRole(state alertQueueState, role string) alertQueueState {
+	if role == alertRoleOverlay {
+		return state.forOverlay()
+	}
+	return state
+}
+
+// handleAlertCommand, komutu kuyruğa uygular. Başarılı komutlar yanıt üretmez; yeni durum
+// yayın üzerinden tüm bağlantılara ulaşır.
+func handleAlertCommand(creatorID primitive.ObjectID, role string, command alertCommand) (alertSocketMessage, bool) {
+	if role == alertRoleOverlay && command.Type != alertCommandEnded {
+		return alertCommandError(command, "Overlay bağlantısı kuyruğu yönetemez"), true
+	}
+
+	var donationID primitive.ObjectID
+	switch command.Type {
+	case alertCommandReplay, alertCommandApprove, alertCommandReject, alertCommandEnded:
+		id, err := primitive.ObjectIDFromHex(command.DonationID)
+		if err != nil {
+			return alertCommandError(command, "Geçersiz bağış kimliği"), true
+		}
+		donationID = id
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var err error
+	switch command.Type {
+	case alertCommandPause:
+		err = pauseAlertQueue(ctx, creatorID, true)
+	case alertCommandResume:
+		err = pauseAlertQueue(ctx, creatorID, false)
+	case alertCommandSkip:
+		err = skipAlert(ctx, creatorID, nil)
+	case alertCommandEnded:
+		err = skipAlert(ctx, creatorID, &donationID)
+	case alertCommandReplay:
+		err = replayAlert(ctx, creatorID, donationID)
+	case alertCommandApprove:
+		err = approveAlert(ctx, creatorID, donationID)
+	case alertCommandReject:
+		err = rejectAlert(ctx, creatorID, donationID)
+	default:
+		return alertCommandError(command, "Bilinmeyen komut"), true
+	}
+
+	switch {
+	case err == nil:
+		return alertSocketMessage{}, false
+	case errors.Is(err, errAlertNotFound):
+		return alertCommandError(command, "Bağış bulunamadı"), true
+	case errors.Is(err, errAlertNotHeld):
+		return alertCommandError(command, "Bu bağış onay beklemiyor"), true
+	case errors.Is(err, errAlertHeld):
+		return alertCommandError(command, "Mesaj onaylanmadan bağış tekrar oynatılamaz"), true
+	default:
+		log.Printf("alert command %s error for %s: %v", command.Type, creatorID.Hex(), err)
+		return alertCommandError(command, "Komut uygulanamadı"), true
+	}
+}
+
+func alertCommandError(command alertCommand, message string) alertSocketMessage {
+	return alertSocketMessage{Type: "error", Command: command.Type, Message: message}
+}
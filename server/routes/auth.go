@@ -2,6 +2,8 @@ package routes
 
 import (
 	"context"
+	"log"
+	"net/mail"
 	"strings"
 	"time"
 
@@ -28,16 +30,25 @@ type loginRequest struct {
 }
 
 type authResponse struct {
-	Token string               `json:"token"`
-	User  models.SanitizedUser `json:"user"`
+	tokenPair
+	User models.SanitizedUser `json:"user"`
 }
 
 func RegisterAuthRoutes(router fiber.Router) {
 	router.Post("/register", registerHandler)
 	router.Post("/login", loginHandler)
+	router.Post("/refresh", refreshHandler)
+	router.Post("/password/forgot", forgotPasswordHandler)
+	router.Post("/password/reset", resetPasswordHandler)
+	router.Post("/email/verify", verifyEmailHandler)
 
 	authProtected := router.Group("", middleware.Protected())
 	authProtected.Get("/me", meHandler)
+	authProtected.Post("/logout", logoutHandler)
+	authProtected.Post("/email/resend", resendVerificationHandler)
+	authProtected.Get("/sessions", listSessionsHandler)
+	authProtected.Post("/sessions/revoke-others", revokeOtherSessionsHandler)
+	authProtected.Delete("/sessions/:id", revokeSessionHandler)
 }
 
 func registerHandler(c *fiber.Ctx) error {
@@ -52,6 +63,9 @@ func registerHandler(c *fiber.Ctx) error {
 	if req.Name == "" || req.Email == "" || req.Username == "" || len(req.Password) < 6 {
 		return utils.Error(c, fiber.StatusBadRequest, "Lütfen tüm alanları doğru doldurun")
 	}
+	if address, err := mail.ParseAddress(req.Email); err != nil || address.Address != req.Email {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçerli bir e-posta adresi gerekli")
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -77,7 +91,8 @@ func registerHandler(c *fiber.Ctx) error {
 		Email:        req.Email,
 		Username:     req.Username,
 		PasswordHash: hash,
-		Wallet:       0,
+		Wallet:       models.Zero(models.DefaultCurrency),
+		HeldBalance:  models.Zero(models.DefaultCurrency),
 		CreatedAt:    time.Now(),
 	}
 
@@ -85,14 +100,18 @@ func registerHandler(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusInternalServerError, "Kullanıcı oluşturulamadı")
 	}
 
-	token, err := utils.GenerateToken(user.ID.Hex())
+	if err := requestVerificationMail(ctx, user); err != nil {
+		log.Printf("verification mail request error for %s: %v", user.ID.Hex(), err)
+	}
+
+	tokens, err := startSession(ctx, c, user.ID)
 	if err != nil {
 		return utils.Error(c, fiber.StatusInternalServerError, "Token oluşturulamadı")
 	}
 
 	return utils.Success(c, fiber.StatusCreated, authResponse{
-		Token: token,
-		User:  user.Sanitize(),
+		tokenPair: tokens,
+		User:      user.Sanitize(),
 	})
 }
 
@@ -114,14 +133,14 @@ func loginHandler(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusUnauthorized, "E-posta veya şifre hatalı")
 	}
 
-	token, err := utils.GenerateToken(user.ID.Hex())
+	tokens, err := startSession(ctx, c, user.ID)
 	if err != nil {
 		return utils.Error(c, fiber.StatusInternalServerError, "Token oluşturulamadı")
 	}
 
 	return utils.Success(c, fiber.StatusOK, authResponse{
-		Token: token,
-		User:  user.Sanitize(),
+		tokenPair: tokens,
+		User:      user.Sanitize(),
 	})
 }
 
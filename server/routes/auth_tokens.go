@@ -0,0 +1,155 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	refreshTokenTTL   = 30 * 24 * time.Hour
+	refreshTokenBytes = 32
+)
+
+var (
+	errRefreshInvalid = errors.New("refresh token invalid")
+	errRefreshReused  = errors.New("refresh token reused")
+)
+
+type refreshRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+type tokenPair struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	ExpiresIn    int64  `json:"expiresIn"`
+}
+
+// issueTokens, aileye yeni bir yenileme belirteci ekler ve eşleşen erişim belirtecini üretir.
+func issueTokens(ctx context.Context, userID, familyID primitive.ObjectID) (tokenPair, models.RefreshToken, error) {
+	plain, err := utils.RandomToken(refreshTokenBytes)
+	if err != nil {
+		return tokenPair{}, models.RefreshToken{}, err
+	}
+
+	now := time.Now().UTC()
+	record := models.RefreshToken{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: utils.HashToken(plain),
+		CreatedAt: now,
+		ExpiresAt: now.Add(refreshTokenTTL),
+	}
+	if _, err := database.Collection("refresh_tokens").InsertOne(ctx, record); err != nil {
+		return tokenPair{}, models.RefreshToken{}, err
+	}
+
+	access, err := utils.GenerateAccessToken(userID.Hex(), familyID.Hex())
+	if err != nil {
+		return tokenPair{}, models.RefreshToken{}, err
+	}
+
+	return tokenPair{
+		Token:        access,
+		RefreshToken: plain,
+		ExpiresIn:    int64(utils.AccessTokenTTL / time.Second),
+	}, record, nil
+}
+
+func refreshHandler(c *fiber.Ctx) error {
+	var req refreshRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+	plain := strings.TrimSpace(req.RefreshToken)
+	if plain == "" {
+		return utils.Error(c, fiber.StatusBadRequest, "Yenileme belirteci gerekli")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	pair, err := rotateRefreshToken(ctx, plain, c.IP())
+	if errors.Is(err, errRefreshInvalid) || errors.Is(err, errRefreshReused) {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum süresi doldu")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Oturum yenilenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, pair)
+}
+
+// rotateRefreshToken, belirteci kullanılmış olarak işaretleyip aynı ailede yenisini verir.
+// Daha önce kullanılmış bir belirteç çalınmış sayılır; bütün aile ve oturumu iptal edilir.
+func rotateRefreshToken(ctx context.Context, plain string, ip string) (tokenPair, error) {
+	var current models.RefreshToken
+	err := database.Collection("refresh_tokens").FindOne(ctx, bson.M{"tokenHash": utils.HashToken(plain)}).Decode(&current)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return tokenPair{}, errRefreshInvalid
+	}
+	if err != nil {
+		return tokenPair{}, err
+	}
+
+	now := time.Now().UTC()
+	if current.UsedAt != nil {
+		revokeTokenFamily(ctx, current.FamilyID, "reuse")
+		log.Printf("refresh token reuse detected for user %s, family %s revoked", current.UserID.Hex(), current.FamilyID.Hex())
+		return tokenPair{}, errRefreshReused
+	}
+	if current.RevokedAt != nil || !now.Before(current.ExpiresAt) {
+		return tokenPair{}, errRefreshInvalid
+	}
+
+	var pair tokenPair
+	err = database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		if err := touchSession(sessCtx, current.FamilyID, ip); err != nil {
+			return err
+		}
+
+		var next models.RefreshToken
+		var err error
+		pair, next, err = issueTokens(sessCtx, current.UserID, current.FamilyID)
+		if err != nil {
+			return err
+		}
+
+		update, err := database.Collection("refresh_tokens").UpdateOne(sessCtx,
+			bson.M{"_id": current.ID, "usedAt": bson.M{"$exists": false}, "revokedAt": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"usedAt": now, "replacedBy": next.ID}},
+		)
+		if err != nil {
+			return err
+		}
+		if update.ModifiedCount == 0 {
+			return errRefreshReused
+		}
+		return nil
+	})
+	if errors.Is(err, errRefreshReused) {
+		// Aynı belirteçle eşzamanlı iki yenileme de tekrar kullanım sayılır.
+		revokeTokenFamily(ctx, current.FamilyID, "reuse")
+		return tokenPair{}, err
+	}
+	return pair, err
+}
+
+func revokeTokenFamily(ctx context.Context, familyID primitive.ObjectID, reason string) {
+	if err := revokeSessions(ctx, []primitive.ObjectID{familyID}, reason); err != nil {
+		log.Printf("refresh token family %s revoke error: %v", familyID.Hex(), err)
+	}
+}
@@ -0,0 +1,497 @@
+package routes
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+const (
+	historySortDateDesc   = "date_desc"
+	historySortDateAsc    = "date_asc"
+	historySortAmountDesc = "amount_desc"
+	historySortAmountAsc  = "amount_asc"
+)
+
+var errInvalidHistoryQuery = errors.New("invalid history query")
+
+// Cüzdan geçmişinde görünen durumlar; bekleyen ve başarısız ödemeler listelenmez.
+var historyStatuses = []models.DonationStatus{
+	models.DonationPaid, models.DonationRefunded, models.DonationChargedBack,
+}
+
+type donationHistoryQuery struct {
+	Limit     int
+	Sort      string
+	Cursor    *historyCursor
+	From      *time.Time
+	To        *time.Time
+	Currency  string
+	MinAmount *int64
+	MaxAmount *int64
+	Donor     string
+	Statuses  []models.DonationStatus
+}
+
+// historyCursor, son görülen kaydın sıralama anahtarını taşır; sayfalar arasında kayıt
+// eklense de atlama ya da tekrar olmaz.
+type historyCursor struct {
+	Sort   string     `json:"s"`
+	Date   *time.Time `json:"d,omitempty"`
+	Amount *int64     `json:"a,omitempty"`
+	ID     string     `json:"id"`
+}
+
+// donationHistoryTotal, bir para birimi ve durum için toplamdır. İade ve ters ibraz edilen
+// bağışlar ayrı satırda döner; ödenmiş tutara karışmaz.
+type donationHistoryTotal struct {
+	Status models.DonationStatus `json:"status"`
+	Count  int64                 `json:"count"`
+	Amount models.Money          `json:"amount"`
+	Net    models.Money          `json:"net"`
+}
+
+type donationHistorySummary struct {
+	Count  int64                  `json:"count"`
+	Totals []donationHistoryTotal `json:"totals"`
+}
+
+type donationHistoryPage struct {
+	Donations  []walletDonation       `json:"donations"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+	Summary    donationHistorySummary `json:"summary"`
+}
+
+func receivedDonationsHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	query, err := parseDonationHistoryQuery(c, user.Wallet.Currency)
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz filtre")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	page, err := receivedDonations(ctx, user.ID, query, true)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağışlar yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, page)
+}
+
+func defaultDonationHistoryQuery() donationHistoryQuery {
+	return donationHistoryQuery{Limit: defaultHistoryLimit, Sort: historySortDateDesc, Statuses: historyStatuses}
+}
+
+func parseDonationHistoryQuery(c *fiber.Ctx, walletCurrency string) (donationHistoryQuery, error) {
+	query := defaultDonationHistoryQuery()
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			return query, errInvalidHistoryQuery
+		}
+		if limit > maxHistoryLimit {
+			limit = maxHistoryLimit
+		}
+		query.Limit = limit
+	}
+
+	if raw := c.Query("sort"); raw != "" {
+		switch raw {
+		case historySortDateDesc, historySortDateAsc, historySortAmountDesc, historySortAmountAsc:
+			query.Sort = raw
+		default:
+			return query, errInvalidHistoryQuery
+		}
+	}
+
+	if raw := c.Query("cursor"); raw != "" {
+		cursor, err := decodeHistoryCursor(raw)
+		if err != nil || cursor.Sort != query.Sort {
+			return query, errInvalidHistoryQuery
+		}
+		query.Cursor = &cursor
+	}
+
+	if raw := c.Query("from"); raw != "" {
+		from, err := parseHistoryDate(raw, false)
+		if err != nil {
+			return query, err
+		}
+		query.From = &from
+	}
+	if raw := c.Query("to"); raw != "" {
+		to, err := parseHistoryDate(raw, true)
+		if err != nil {
+			return query, err
+		}
+		query.To = &to
+	}
+
+	if raw := c.Query("currency"); raw != "" {
+		currency, err := models.NormalizeCurrency(raw)
+		if err != nil {
+			return query, errInvalidHistoryQuery
+		}
+		query.Currency = currency
+	}
+
+	// Tutar aralığı tek bir para birimi içinde anlamlıdır; belirtilmemişse cüzdanınki kullanılır.
+	amountCurrency := query.Currency
+	if amountCurrency == "" {
+		amountCurrency = walletCurrency
+	}
+	if raw := c.Query("minAmount"); raw != "" {
+		amount, err := models.ParseMoney(raw, amountCurrency)
+		if err != nil {
+			return query, errInvalidHistoryQuery
+		}
+		query.MinAmount = &amount.Minor
+		query.Currency = amount.Currency
+	}
+	if raw := c.Query("maxAmount"); raw != "" {
+		amount, err := models.ParseMoney(raw, amountCurrency)
+		if err != nil {
+			return query, errInvalidHistoryQuery
+		}
+		query.MaxAmount = &amount.Minor
+		query.Currency = amount.Currency
+	}
+
+	query.Donor = strings.TrimSpace(c.Query("donor"))
+
+	if raw := c.Query("status"); raw != "" {
+		statuses := make([]models.DonationStatus, 0)
+		for _, value := range strings.Split(raw, ",") {
+			status := models.DonationStatus(strings.TrimSpace(value))
+			valid := false
+			for _, allowed := range historyStatuses {
+				if status == allowed {
+					valid = true
+				}
+			}
+			if !valid {
+				return query, errInvalidHistoryQuery
+			}
+			statuses = append(statuses, status)
+		}
+		query.Statuses = statuses
+	}
+
+	return query, nil
+}
+
+// parseHistoryDate, RFC3339 ya da YYYY-MM-DD kabul eder; bitiş için verilen gün dahildir.
+func parseHistoryDate(value string, endOfDay bool) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed.UTC(), nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errInvalidHistoryQuery
+	}
+	if endOfDay {
+		parsed = parsed.Add(24*time.Hour - time.Nanosecond)
+	}
+	return parsed, nil
+}
+
+// historyFilter, sayfadan bağımsız filtreyi kurar; özet bu filtreyle, liste ise buna imleç
+// koşulu eklenerek sorgulanır. Tarih aralığı ve sıralama, döküm ve overlay ile aynı dönemi
+// vermek için oluşturulma değil ödeme zamanına (paidAt) göredir.
+func historyFilter(ctx context.Context, base bson.M, query donationHistoryQuery) (bson.M, error) {
+	filter := bson.M{}
+	for key, value := range base {
+		filter[key] = value
+	}
+
+	statuses := make(bson.A, 0, len(query.Statuses))
+	for _, status := range query.Statuses {
+		statuses = append(statuses, status)
+	}
+	filter["status"] = bson.M{"$in": statuses}
+
+	if query.From != nil || query.To != nil {
+		date := bson.M{}
+		if query.From != nil {
+			date["$gte"] = *query.From
+		}
+		if query.To != nil {
+			date["$lte"] = *query.To
+		}
+		filter["paidAt"] = date
+	}
+
+	if query.Currency != "" {
+		filter["amount.currency"] = query.Currency
+	}
+	if query.MinAmount != nil || query.MaxAmount != nil {
+		amount := bson.M{}
+		if query.MinAmount != nil {
+			amount["$gte"] = *query.MinAmount
+		}
+		if query.MaxAmount != nil {
+			amount["$lte"] = *query.MaxAmount
+		}
+		filter["amount.minor"] = amount
+	}
+
+	if query.Donor != "" {
+		// Anonim bağışlar bağışçı adıyla aranamaz; aksi halde kimlik filtreden çıkarılabilirdi.
+		donor := bson.A{
+			bson.M{"donorName": bson.M{"$regex": "^" + regexp.QuoteMeta(query.Donor), "$options": "i"}},
+		}
+		var donorUser models.User
+		err := database.Collection("users").FindOne(ctx, bson.M{"username": strings.ToLower(query.Donor)}).Decode(&donorUser)
+		switch {
+		case err == nil:
+			donor = append(donor, bson.M{"fromUserId": donorUser.ID})
+		case !errors.Is(err, mongo.ErrNoDocuments):
+			return nil, err
+		}
+		filter["anonymous"] = bson.M{"$ne": true}
+		filter["$or"] = donor
+	}
+
+	return filter, nil
+}
+
+func historySortStage(sort string) bson.D {
+	switch sort {
+	case historySortDateAsc:
+		return bson.D{{Key: "paidAt", Value: 1}, {Key: "_id", Value: 1}}
+	case historySortAmountDesc:
+		return bson.D{{Key: "amount.minor", Value: -1}, {Key: "_id", Value: -1}}
+	case historySortAmountAsc:
+		return bson.D{{Key: "amount.minor", Value: 1}, {Key: "_id", Value: 1}}
+	default:
+		return bson.D{{Key: "paidAt", Value: -1}, {Key: "_id", Value: -1}}
+	}
+}
+
+func historyCursorCondition(cursor *historyCursor) (bson.M, error) {
+	id, err := primitive.ObjectIDFromHex(cursor.ID)
+	if err != nil {
+		return nil, errInvalidHistoryQuery
+	}
+
+	op := "$lt"
+	if cursor.Sort == historySortDateAsc || cursor.Sort == historySortAmountAsc {
+		op = "$gt"
+	}
+
+	field := "paidAt"
+	var value interface{}
+	switch {
+	case (cursor.Sort == historySortDateDesc || cursor.Sort == historySortDateAsc) && cursor.Date != nil:
+		value = *cursor.Date
+	case (cursor.Sort == historySortAmountDesc || cursor.Sort == historySortAmountAsc) && cursor.Amount != nil:
+		field = "amount.minor"
+		value = *cursor.Amount
+	default:
+		return nil, errInvalidHistoryQuery
+	}
+
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{op: id}},
+	}}, nil
+}
+
+func encodeHistoryCursor(cursor historyCursor) string {
+	payload, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func decodeHistoryCursor(value string) (historyCursor, error) {
+	var cursor historyCursor
+	payload, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, err
+	}
+	err = json.Unmarshal(payload, &cursor)
+	return cursor, err
+}
+
+// receivedDonations, yaratıcıya gelen bağışların bir sayfasını döndürür. withSummary açıksa
+// filtrelenmiş kümenin tamamı için adet ve para birimi bazında toplamlar da hesaplanır.
+func receivedDonations(ctx context.Context, userID primitive.ObjectID, query donationHistoryQuery, withSummary bool) (donationHistoryPage, error) {
+	page := donationHistoryPage{Donations: make([]walletDonation, 0), Summary: donationHistorySummary{Totals: []donationHistoryTotal{}}}
+
+	filter, err := historyFilter(ctx, bson.M{"toUserId": userID}, query)
+	if err != nil {
+		return page, err
+	}
+
+	match := filter
+	if query.Cursor != nil {
+		condition, err := historyCursorCondition(query.Cursor)
+		if err != nil {
+			return page, err
+		}
+		match = bson.M{"$and": bson.A{filter, condition}}
+	}
+
+	cursor, err := database.Collection("donations").Aggregate(ctx, bson.A{
+		bson.M{"$match": match},
+		bson.M{"$sort": historySortStage(query.Sort)},
+		bson.M{"$limit": query.Limit + 1},
+		bson.M{"$lookup": bson.M{
+			"from":         "users",
+			"localField":   "fromUserId",
+			"foreignField": "_id",
+			"as":           "fromUser",
+		}},
+		bson.M{"$unwind": bson.M{"path": "$fromUser", "preserveNullAndEmptyArrays": true}},
+		bson.M{"$project": bson.M{
+			"amount":          1,
+			"platformFee":     1,
+			"processorFee":    1,
+			"net":             1,
+			"paidAt":          1,
+			"status":          1,
+			"statusChangedAt": 1,
+			"message":         1,
+			"displayName":     1,
+			"fromUserName":    publicDonorName(),
+		}},
+	})
+	if err != nil {
+		return page, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID              primitive.ObjectID    `bson:"_id"`
+			Amount          models.Money          `bson:"amount"`
+			PlatformFee     models.Money          `bson:"platformFee"`
+			ProcessorFee    models.Money          `bson:"processorFee"`
+			Net             models.Money          `bson:"net"`
+			PaidAt          time.Time             `bson:"paidAt"`
+			Status          models.DonationStatus `bson:"status"`
+			StatusChangedAt time.Time             `bson:"statusChangedAt"`
+			Message         string                `bson:"message"`
+			DisplayName     string                `bson:"displayName"`
+			FromUserName    string                `bson:"fromUserName"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+
+		if len(page.Donations) == query.Limit {
+			last := page.Donations[len(page.Donations)-1]
+			page.NextCursor = encodeHistoryCursor(nextHistoryCursor(query.Sort, last.ID, last.Date, last.Amount))
+			break
+		}
+
+		item := walletDonation{
+			ID:           doc.ID.Hex(),
+			Amount:       doc.Amount,
+			PlatformFee:  &doc.PlatformFee,
+			ProcessorFee: &doc.ProcessorFee,
+			Net:          &doc.Net,
+			Date:         doc.PaidAt,
+			FromUserName: doc.FromUserName,
+			DisplayName:  doc.DisplayName,
+			Message:      doc.Message,
+			Status:       doc.Status,
+		}
+		if doc.Status != models.DonationPaid {
+			reversedAt := doc.StatusChangedAt
+			item.ReversedAt = &reversedAt
+		}
+		page.Donations = append(page.Donations, item)
+	}
+	if err := cursor.Err(); err != nil {
+		return page, err
+	}
+
+	if withSummary {
+		summary, err := summarizeDonationHistory(ctx, filter)
+		if err != nil {
+			return page, err
+		}
+		page.Summary = summary
+	}
+
+	return page, nil
+}
+
+func nextHistoryCursor(sort, id string, date time.Time, amount models.Money) historyCursor {
+	cursor := historyCursor{Sort: sort, ID: id}
+	switch sort {
+	case historySortAmountDesc, historySortAmountAsc:
+		cursor.Amount = &amount.Minor
+	default:
+		cursor.Date = &date
+	}
+	return cursor
+}
+
+func summarizeDonationHistory(ctx context.Context, filter bson.M) (donationHistorySummary, error) {
+	summary := donationHistorySummary{Totals: []donationHistoryTotal{}}
+
+	cursor, err := database.Collection("donations").Aggregate(ctx, bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{
+			"_id":    bson.M{"currency": "$amount.currency", "status": "$status"},
+			"count":  bson.M{"$sum": 1},
+			"amount": bson.M{"$sum": "$amount.minor"},
+			"net":    bson.M{"$sum": "$net.minor"},
+		}},
+		bson.M{"$sort": bson.D{{Key: "_id.currency", Value: 1}, {Key: "_id.status", Value: 1}}},
+	})
+	if err != nil {
+		return summary, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID struct {
+				Currency string                `bson:"currency"`
+				Status   models.DonationStatus `bson:"status"`
+			} `bson:"_id"`
+			Count  int64 `bson:"count"`
+			Amount int64 `bson:"amount"`
+			Net    int64 `bson:"net"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return summary, err
+		}
+		summary.Count += doc.Count
+		summary.Totals = append(summary.Totals, donationHistoryTotal{
+			Status: doc.ID.Status,
+			Count:  doc.Count,
+			Amount: models.NewMoney(doc.Amount, doc.ID.Currency),
+			Net:    models.NewMoney(doc.Net, doc.ID.Currency),
+		})
+	}
+	return summary, cursor.Err()
+}
@@ -0,0 +1,195 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/events"
+	"donation-app/server/ledger"
+	"donation-app/server/models"
+)
+
+type transitionEffect func(sessCtx mongo.SessionContext, donation models.Donation) error
+
+// Cüzdan ve ledger hareketleri bağış eklenirken değil, yalnızca bu geçişlerde yapılır.
+var transitionEffects = map[models.DonationStatus]transitionEffect{
+	models.DonationPaid:        creditWallet,
+	models.DonationRefunded:    reverseWalletCredit,
+	models.DonationChargedBack: reverseWalletCredit,
+}
+
+type donationTransition struct {
+	To     models.DonationStatus
+	Reason string
+	Set    bson.M
+}
+
+// transitionDonation, bağışı verilen duruma geçirir ve geçişe bağlı etkileri aynı işlem
+// içinde uygular. Bağış zaten hedef durumdaysa hiçbir şey yapmadan changed=false döner.
+func transitionDonation(ctx context.Context, donationID primitive.ObjectID, transition donationTransition) (donation models.Donation, changed bool, err error) {
+	err = database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		changed = false
+		donation = models.Donation{}
+		if err := database.Collection("donations").FindOne(sessCtx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+			return err
+		}
+
+		if donation.Status == transition.To {
+			return nil
+		}
+		if !donation.Status.CanTransitionTo(transition.To) {
+			return models.ErrInvalidTransition
+		}
+
+		now := time.Now().UTC()
+		set := bson.M{"status": transition.To, "statusChangedAt": now}
+		for key, value := range transition.Set {
+			set[key] = value
+		}
+		if transition.To == models.DonationPaid {
+			set["paidAt"] = now
+		}
+
+		record := models.DonationTransition{From: donation.Status, To: transition.To, At: now, Reason: transition.Reason}
+		update, err := database.Collection("donations").UpdateOne(sessCtx,
+			bson.M{"_id": donation.ID, "status": donation.Status},
+			bson.M{"$set": set, "$push": bson.M{"transitions": record}},
+		)
+		if err != nil {
+			return err
+		}
+		if update.ModifiedCount == 0 {
+			return models.ErrInvalidTransition
+		}
+
+		donation.Status = transition.To
+		donation.StatusChangedAt = now
+		donation.Transitions = append(donation.Transitions, record)
+		if transition.To == models.DonationPaid {
+			donation.PaidAt = &now
+		}
+
+		if effect, ok := transitionEffects[transition.To]; ok {
+			if err := effect(sessCtx, donation); err != nil {
+				return err
+			}
+		}
+
+		changed = true
+		return nil
+	})
+
+	if err == nil && changed {
+		notifyTransition(donation)
+	}
+	return donation, changed, err
+}
+
+// notifyTransition, işlem kaydedildikten sonra çalışır; yeniden denenen işlemler yayını çoğaltmaz.
+func notifyTransition(donation models.Donation) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	switch donation.Status {
+	case models.DonationPaid:
+		reviewed, err := holdMessageForReview(ctx, donation)
+		if err != nil {
+			// Onay durumu bilinmiyorsa mesaj yayınlanmaz ve bağış kuyruğa bekletilmiş olarak eklenir.
+			log.Printf("message review error for donation %s: %v", donation.ID.Hex(), err)
+			reviewed.MessageReview = models.MessageReviewHeld
+		}
+		donation = reviewed
+		events.Publish(donation.ToUserID, donationPaidEvent(donation))
+		if err := enqueueAlert(ctx, donation); err != nil {
+			log.Printf("alert enqueue error for donation %s: %v", donation.ID.Hex(), err)
+		}
+	case models.DonationRefunded, models.DonationChargedBack:
+		if err := removeAlert(ctx, donation); err != nil {
+			log.Printf("alert removal error for donation %s: %v", donation.ID.Hex(), err)
+		}
+	default:
+		return
+	}
+
+	if err := refreshGoals(ctx, donation.ToUserID); err != nil {
+		log.Printf("goal refresh error for %s: %v", donation.ToUserID.Hex(), err)
+	}
+}
+
+// creditWallet, brüt tutarı tahsilat hesabına alır; net tutarı cüzdana, komisyonları
+// platform ve ödeme kuruluşu hesaplarına dağıtır.
+func creditWallet(sessCtx mongo.SessionContext, donation models.Donation) error {
+	wallet := ledger.WalletAccount(donation.ToUserID)
+
+	lines := []ledger.Line{
+		{Account: ledger.AccountClearing, Debit: donation.Amount.Minor},
+		{Account: wallet, Credit: donation.Net.Minor},
+	}
+	if donation.PlatformFee.IsPositive() {
+		lines = append(lines, ledger.Line{Account: ledger.AccountFees, Credit: donation.PlatformFee.Minor})
+	}
+	if donation.ProcessorFee.IsPositive() {
+		lines = append(lines, ledger.Line{Account: ledger.AccountProcessorFees, Credit: donation.ProcessorFee.Minor})
+	}
+
+	entry, err := ledger.Post(sessCtx, ledger.Entry{
+		Kind:      ledger.KindDonation,
+		Currency:  donation.Amount.Currency,
+		Lines:     lines,
+		Reference: donation.ID.Hex(),
+	})
+	if err != nil {
+		return err
+	}
+
+	return applyWalletDelta(sessCtx, donation.ToUserID, entry.Net(wallet))
+}
+
+// reverseWalletCredit, bağışın cüzdana yazdığı kaydı ters çevirir. Para zaten çekilmişse
+// bakiye eksiye düşebilir; bu durumda kullanıcı negativeBalance ile işaretlenir.
+func reverseWalletCredit(sessCtx mongo.SessionContext, donation models.Donation) error {
+	wallet := ledger.WalletAccount(donation.ToUserID)
+	memo := string(donation.Status)
+
+	original, err := ledger.FindByReference(sessCtx, ledger.KindDonation, donation.ID.Hex())
+	var entry ledger.Entry
+	switch {
+	case err == nil:
+		entry, err = ledger.Reverse(sessCtx, original, ledger.KindRefund, memo)
+	case errors.Is(err, mongo.ErrNoDocuments):
+		// Ledger'dan önce ödenmiş bağışların donation kaydı yoktur; tutar doğrudan geri alınır.
+		entry, err = ledger.Move(sessCtx, ledger.KindRefund, wallet, ledger.AccountClearing, donation.Amount, donation.ID.Hex(), memo)
+	}
+	if err != nil {
+		return err
+	}
+
+	return applyWalletDelta(sessCtx, donation.ToUserID, entry.Net(wallet))
+}
+
+func applyWalletDelta(sessCtx mongo.SessionContext, userID primitive.ObjectID, delta models.Money) error {
+	var user models.User
+	err := database.Collection("users").FindOneAndUpdate(sessCtx,
+		bson.M{"_id": userID},
+		bson.M{"$inc": bson.M{"wallet.minor": delta.Minor}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&user)
+	if err != nil {
+		return err
+	}
+
+	negative := user.Wallet.IsNegative()
+	if negative == user.NegativeBalance {
+		return nil
+	}
+	_, err = database.Collection("users").UpdateByID(sessCtx, userID, bson.M{"$set": bson.M{"negativeBalance": negative}})
+	return err
+}
@@ -2,37 +2,66 @@ package routes
 
 import (
 	"context"
-	"math"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gofiber/fiber/v2"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 
 	"donation-app/server/database"
+	"donation-app/server/fees"
 	"donation-app/server/middleware"
 	"donation-app/server/models"
+	"donation-app/server/moderation"
+	"donation-app/server/payments"
 	"donation-app/server/utils"
 )
 
 type createDonationRequest struct {
-	Amount     float64 `json:"amount"`
-	ToUsername string  `json:"toUsername"`
+	Amount      json.Number `json:"amount"`
+	Currency    string      `json:"currency"`
+	ToUsername  string      `json:"toUsername"`
+	Message     string      `json:"message"`
+	DisplayName string      `json:"displayName"`
+	Anonymous   bool        `json:"anonymous"`
+	DonorName   string      `json:"donorName"`
+	DonorEmail  string      `json:"donorEmail"`
 }
 
 type walletDonation struct {
-	ID           string    `json:"id"`
-	Amount       float64   `json:"amount"`
-	Date         time.Time `json:"date"`
-	FromUserName string    `json:"fromUserName,omitempty"`
+	ID           string                `json:"id"`
+	Amount       models.Money          `json:"amount"`
+	PlatformFee  *models.Money         `json:"platformFee,omitempty"`
+	ProcessorFee *models.Money         `json:"processorFee,omitempty"`
+	Net          *models.Money         `json:"net,omitempty"`
+	Date         time.Time             `json:"date"`
+	FromUserName string                `json:"fromUserName,omitempty"`
+	DisplayName  string                `json:"displayName,omitempty"`
+	Message      string                `json:"message,omitempty"`
+	Status       models.DonationStatus `json:"status,omitempty"`
+	ReversedAt   *time.Time            `json:"reversedAt,omitempty"`
 }
 
+// Bu rotalar /api kökünde olduğu için koruma grup yerine rota bazında eklenir; aksi halde
+// sonradan kaydedilen /api rotaları (ödeme bildirimleri gibi) da yetki ister.
 func RegisterDonationRoutes(router fiber.Router) {
-	protected := router.Group("", middleware.Protected())
-	protected.Get("/wallet", walletHandler)
-	protected.Post("/donations", createDonationHandler)
-	protected.Get("/donations/selected", selectedDonationsHandler)
+	protected := middleware.Protected()
+	router.Get("/wallet", protected, walletHandler)
+	router.Get("/wallet/donations", protected, receivedDonationsHandler)
+	router.Get("/wallet/export", protected, walletExportHandler)
+	router.Get("/wallet/audit", protected, walletAuditHandler)
+	router.Post("/donations", middleware.OptionalAuth(), middleware.Idempotency(), createDonationHandler)
+	router.Get("/donations/selected", protected, selectedDonationsHandler)
+	router.Get("/donations/sent", protected, sentDonationsHandler)
+	router.Get("/donations/:id/receipt", protected, donationReceiptHandler)
+	router.Post("/donations/:id/refund", protected, middleware.Idempotency(), refundDonationHandler)
 }
 
 func selectedDonationsHandler(c *fiber.Ctx) error {
@@ -41,6 +70,10 @@ func selectedDonationsHandler(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
 	}
 
+	return selectedDonations(c, user.ID)
+}
+
+func selectedDonations(c *fiber.Ctx, ownerID primitive.ObjectID) error {
 	idParam := strings.TrimSpace(c.Query("ids"))
 	if idParam == "" {
 		return utils.Error(c, fiber.StatusBadRequest, "Seçili bağış bulunamadı")
@@ -68,7 +101,7 @@ func selectedDonationsHandler(c *fiber.Ctx) error {
 	defer cancel()
 
 	cursor, err := database.Collection("donations").Aggregate(ctx, bson.A{
-		bson.M{"$match": bson.M{"_id": bson.M{"$in": objectIDs}, "toUserId": user.ID}},
+		bson.M{"$match": bson.M{"_id": bson.M{"$in": objectIDs}, "toUserId": ownerID, "status": models.DonationPaid}},
 		bson.M{"$lookup": bson.M{
 			"from":         "users",
 			"localField":   "fromUserId",
@@ -77,10 +110,14 @@ func selectedDonationsHandler(c *fiber.Ctx) error {
 		}},
 		bson.M{"$unwind": bson.M{"path": "$fromUser", "preserveNullAndEmptyArrays": true}},
 		bson.M{"$project": bson.M{
-			"amount":       1,
-			"date":         1,
-			"fromUserName": "$fromUser.name",
-			"_id":          1,
+			"amount":          1,
+			"date":            1,
+			"message":         1,
+			"displayName":     1,
+			"messageFiltered": 1,
+			"messageReview":   1,
+			"fromUserName":    publicDonorName(),
+			"_id":             1,
 		}},
 		bson.M{"$sort": bson.M{"date": -1}},
 	})
@@ -92,20 +129,30 @@ func selectedDonationsHandler(c *fiber.Ctx) error {
 	results := make([]walletDonation, 0, len(objectIDs))
 	for cursor.Next(ctx) {
 		var doc struct {
-			ID           primitive.ObjectID `bson:"_id"`
-			Amount       float64            `bson:"amount"`
-			Date         time.Time          `bson:"date"`
-			FromUserName string             `bson:"fromUserName"`
+			ID              primitive.ObjectID   `bson:"_id"`
+			Amount          models.Money         `bson:"amount"`
+			Date            time.Time            `bson:"date"`
+			Message         string               `bson:"message"`
+			DisplayName     string               `bson:"displayName"`
+			FromUserName    string               `bson:"fromUserName"`
+			MessageFiltered bool                 `bson:"messageFiltered"`
+			MessageReview   models.MessageReview `bson:"messageReview"`
 		}
 		if err := cursor.Decode(&doc); err != nil {
 			continue
 		}
-		results = append(results, walletDonation{
+		result := walletDonation{
 			ID:           doc.ID.Hex(),
 			Amount:       doc.Amount,
 			Date:         doc.Date,
 			FromUserName: doc.FromUserName,
-		})
+		}
+		// Seçili bağışlar overlay'de gösterildiği için onaylanmamış mesajlar boş döner.
+		if (models.Donation{MessageFiltered: doc.MessageFiltered, MessageReview: doc.MessageReview}).MessagePublic() {
+			result.DisplayName = doc.DisplayName
+			result.Message = doc.Message
+		}
+		results = append(results, result)
 	}
 
 	return utils.Success(c, fiber.StatusOK, fiber.Map{"donations": results})
@@ -117,8 +164,8 @@ func createDonationHandler(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
 	}
 
-	amount := math.Round(req.Amount*100) / 100
-	if amount <= 0 {
+	amount, err := models.ParseMoney(req.Amount.String(), req.Currency)
+	if err != nil || !amount.IsPositive() {
 		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz tutar")
 	}
 
@@ -127,13 +174,25 @@ func createDonationHandler(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusBadRequest, "Hedef kullanıcı gerekli")
 	}
 
-	donor, ok := c.Locals("user").(models.User)
-	if !ok {
-		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
-	}
-
-	if donor.Username == target {
-		return utils.Error(c, fiber.StatusBadRequest, "Kendine bağış yapamazsın")
+	donor, loggedIn := c.Locals("user").(models.User)
+	donorName := strings.TrimSpace(req.DonorName)
+	donorEmail := strings.TrimSpace(strings.ToLower(req.DonorEmail))
+	if loggedIn {
+		if donor.Username == target {
+			return utils.Error(c, fiber.StatusBadRequest, "Kendine bağış yapamazsın")
+		}
+		donorName = strings.TrimSpace(donor.Name)
+		if donorName == "" {
+			donorName = donor.Username
+		}
+		donorEmail = donor.Email
+	} else {
+		if donorName == "" || utf8.RuneCountInString(donorName) > models.MaxDisplayNameLength {
+			return utils.Error(c, fiber.StatusBadRequest, "Bağışçı adı gerekli")
+		}
+		if _, err := mail.ParseAddress(donorEmail); err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçerli bir e-posta adresi gerekli")
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -143,40 +202,104 @@ func createDonationHandler(c *fiber.Ctx) error {
 	if err := database.Collection("users").FindOne(ctx, bson.M{"username": target}).Decode(&recipient); err != nil {
 		return utils.Error(c, fiber.StatusNotFound, "Hedef kullanıcı bulunamadı")
 	}
+	if !recipient.EmailVerified {
+		return utils.Error(c, fiber.StatusNotFound, "Hedef kullanıcı bulunamadı")
+	}
 
-	donation := models.Donation{
-		ID:         primitive.NewObjectID(),
-		FromUserID: donor.ID,
-		ToUserID:   recipient.ID,
-		Amount:     amount,
-		Date:       time.Now().UTC(),
+	if recipient.Wallet.Currency != amount.Currency {
+		return utils.Error(c, fiber.StatusBadRequest, "Alıcı bu para birimini kabul etmiyor")
 	}
 
-	if _, err := database.Collection("donations").InsertOne(ctx, donation); err != nil {
-		return utils.Error(c, fiber.StatusInternalServerError, "Bağış kaydedilemedi")
+	message := strings.TrimSpace(req.Message)
+	displayName := strings.TrimSpace(req.DisplayName)
+	limits := recipient.MessageLimits()
+	if utf8.RuneCountInString(message) > limits.MaxLength {
+		return utils.Error(c, fiber.StatusBadRequest, fmt.Sprintf("Mesaj en fazla %d karakter olabilir", limits.MaxLength))
+	}
+	if message != "" && amount.Minor < limits.MinAmount.Minor {
+		return utils.Error(c, fiber.StatusBadRequest, fmt.Sprintf("Mesaj göndermek için en az %s bağışlamalısın", limits.MinAmount))
+	}
+	if utf8.RuneCountInString(displayName) > models.MaxDisplayNameLength {
+		return utils.Error(c, fiber.StatusBadRequest, fmt.Sprintf("Görünen ad en fazla %d karakter olabilir", models.MaxDisplayNameLength))
+	}
+
+	filteredMessage := moderation.Check(message)
+	filteredName := moderation.Check(displayName)
+
+	breakdown, err := fees.ForTier(recipient.Tier).Apply(amount)
+	if errors.Is(err, fees.ErrFixedFeeNotConfigured) {
+		return utils.Error(c, fiber.StatusBadRequest, "Bu para birimi için işlem ücreti tanımlı değil")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Tutar işlem ücretlerini karşılamıyor")
+	}
+
+	recipientName := strings.TrimSpace(recipient.Name)
+	if recipientName == "" {
+		recipientName = recipient.Username
+	}
+
+	provider := payments.Active()
+	now := time.Now().UTC()
+	donation := models.Donation{
+		ID:              primitive.NewObjectID(),
+		FromUserID:      donor.ID,
+		DonorName:       donorName,
+		DonorEmail:      donorEmail,
+		Anonymous:       req.Anonymous,
+		ToUserID:        recipient.ID,
+		Amount:          breakdown.Gross,
+		PlatformFee:     breakdown.PlatformFee,
+		ProcessorFee:    breakdown.ProcessorFee,
+		Net:             breakdown.Net,
+		Message:         filteredMessage.Text,
+		DisplayName:     filteredName.Text,
+		MessageFiltered: filteredMessage.Flagged || filteredName.Flagged,
+		Date:            now,
+		Status:          models.DonationPending,
+		StatusChangedAt: now,
+		Provider:        provider.Name(),
 	}
 
-	if _, err := database.Collection("users").UpdateByID(ctx, recipient.ID, bson.M{"$inc": bson.M{"wallet": amount}}); err != nil {
-		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan güncellenemedi")
+	profileURL := fmt.Sprintf("%s/profile/%s", utils.FrontendURL(), recipient.Username)
+	checkout, err := provider.CreateCheckout(ctx, payments.CheckoutRequest{
+		OrderID:     donation.ID.Hex(),
+		Amount:      amount,
+		Description: fmt.Sprintf("Bağış - %s", recipientName),
+		Buyer: payments.Buyer{
+			Name:  donorName,
+			Email: donorEmail,
+			IP:    c.IP(),
+		},
+		SuccessURL: profileURL + "?payment=success",
+		FailURL:    profileURL + "?payment=failed",
+	})
+	if err != nil {
+		log.Printf("checkout error (%s): %v", provider.Name(), err)
+		return utils.Error(c, fiber.StatusBadGateway, "Ödeme başlatılamadı")
 	}
+	donation.SessionID = checkout.SessionID
 
-	recipient.Wallet += amount
-	donorName := strings.TrimSpace(donor.Name)
-	if donorName == "" {
-		donorName = donor.Username
+	if _, err := database.Collection("donations").InsertOne(ctx, donation); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağış kaydedilemedi")
 	}
 
 	return utils.Success(c, fiber.StatusCreated, fiber.Map{
 		"donation": fiber.Map{
 			"id":           donation.ID.Hex(),
 			"amount":       donation.Amount,
+			"fees":         breakdown,
 			"date":         donation.Date,
+			"status":       donation.Status,
+			"anonymous":    donation.Anonymous,
 			"fromUserName": donorName,
+			"displayName":  donation.DisplayName,
+			"message":      donation.Message,
 		},
+		"checkout": checkout,
 		"recipient": fiber.Map{
 			"username": recipient.Username,
 			"name":     recipient.Name,
-			"wallet":   recipient.Wallet,
 		},
 	})
 }
@@ -195,49 +318,76 @@ func walletHandler(c *fiber.Ctx) error {
 		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan yüklenemedi")
 	}
 
+	page, err := receivedDonations(ctx, user.ID, defaultDonationHistoryQuery(), false)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan yüklenemedi")
+	}
+
+	totals, err := sumDonationFees(ctx, user.ID, freshUser.Wallet.Currency)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{
+		"wallet":          freshUser.Wallet,
+		"totals":          totals,
+		"negativeBalance": freshUser.NegativeBalance,
+		"heldBalance":     freshUser.HeldBalance,
+		"donations":       page.Donations,
+		"nextCursor":      page.NextCursor,
+	})
+}
+
+func sumDonationFees(ctx context.Context, userID primitive.ObjectID, currency string) (fees.Breakdown, error) {
+	totals := fees.Breakdown{
+		Gross:        models.Zero(currency),
+		PlatformFee:  models.Zero(currency),
+		ProcessorFee: models.Zero(currency),
+		Net:          models.Zero(currency),
+	}
+
 	cursor, err := database.Collection("donations").Aggregate(ctx, bson.A{
-		bson.M{"$match": bson.M{"toUserId": user.ID}},
-		bson.M{"$sort": bson.M{"date": -1}},
-		bson.M{"$limit": 20},
-		bson.M{"$lookup": bson.M{
-			"from":         "users",
-			"localField":   "fromUserId",
-			"foreignField": "_id",
-			"as":           "fromUser",
-		}},
-		bson.M{"$unwind": bson.M{"path": "$fromUser", "preserveNullAndEmptyArrays": true}},
-		bson.M{"$project": bson.M{
-			"amount":       1,
-			"date":         1,
-			"fromUserName": "$fromUser.name",
+		bson.M{"$match": bson.M{"toUserId": userID, "status": models.DonationPaid, "amount.currency": currency}},
+		bson.M{"$group": bson.M{
+			"_id":          nil,
+			"gross":        bson.M{"$sum": "$amount.minor"},
+			"platformFee":  bson.M{"$sum": "$platformFee.minor"},
+			"processorFee": bson.M{"$sum": "$processorFee.minor"},
+			"net":          bson.M{"$sum": "$net.minor"},
 		}},
 	})
 	if err != nil {
-		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan yüklenemedi")
+		return totals, err
 	}
 	defer cursor.Close(ctx)
 
-	donations := make([]walletDonation, 0)
-	for cursor.Next(ctx) {
-		var doc struct {
-			ID           primitive.ObjectID `bson:"_id"`
-			Amount       float64            `bson:"amount"`
-			Date         time.Time          `bson:"date"`
-			FromUserName string             `bson:"fromUserName"`
-		}
+	var doc struct {
+		Gross        int64 `bson:"gross"`
+		PlatformFee  int64 `bson:"platformFee"`
+		ProcessorFee int64 `bson:"processorFee"`
+		Net          int64 `bson:"net"`
+	}
+	if cursor.Next(ctx) {
 		if err := cursor.Decode(&doc); err != nil {
-			continue
+			return totals, err
 		}
-		donations = append(donations, walletDonation{
-			ID:           doc.ID.Hex(),
-			Amount:       doc.Amount,
-			Date:         doc.Date,
-			FromUserName: doc.FromUserName,
-		})
 	}
 
-	return utils.Success(c, fiber.StatusOK, fiber.Map{
-		"wallet":    freshUser.Wallet,
-		"donations": donations,
-	})
+	totals.Gross.Minor = doc.Gross
+	totals.PlatformFee.Minor = doc.PlatformFee
+	totals.ProcessorFee.Minor = doc.ProcessorFee
+	totals.Net.Minor = doc.Net
+	return totals, cursor.Err()
+}
+
+const anonymousDonorName = "Anonim"
+
+// publicDonorName, alıcıya ve overlay'e gösterilecek bağışçı adını üretir. Anonim bağışlarda
+// gerçek kimlik kayıtta kalır ama dışarı verilmez.
+func publicDonorName() bson.M {
+	return bson.M{"$cond": bson.A{
+		bson.M{"$eq": bson.A{"$anonymous", true}},
+		anonymousDonorName,
+		bson.M{"$ifNull": bson.A{"$fromUser.name", "$donorName"}},
+	}}
 }
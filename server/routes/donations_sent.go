@@ -0,0 +1,243 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const maxRecipientTotals = 100
+
+type sentDonation struct {
+	ID                string                `json:"id"`
+	Amount            models.Money          `json:"amount"`
+	Date              time.Time             `json:"date"`
+	Status            models.DonationStatus `json:"status"`
+	Message           string                `json:"message,omitempty"`
+	Anonymous         bool                  `json:"anonymous"`
+	RecipientName     string                `json:"recipientName"`
+	RecipientUsername string                `json:"recipientUsername"`
+}
+
+// recipientTotal, alıcıya ödenmiş bağışların toplamıdır; iade ve ters ibraz edilenler
+// Reversed alanlarında ayrı tutulur.
+type recipientTotal struct {
+	RecipientName     string       `json:"recipientName"`
+	RecipientUsername string       `json:"recipientUsername"`
+	Count             int64        `json:"count"`
+	Amount            models.Money `json:"amount"`
+	ReversedCount     int64        `json:"reversedCount"`
+	Reversed          models.Money `json:"reversed"`
+}
+
+type sentDonationsPage struct {
+	Donations  []sentDonation         `json:"donations"`
+	NextCursor string                 `json:"nextCursor,omitempty"`
+	Summary    donationHistorySummary `json:"summary"`
+	Recipients []recipientTotal       `json:"recipients"`
+}
+
+// sentDonationsHandler, bağışçının yaptığı bağışları listeler. Filtreler ve imleç gelen
+// bağış geçmişiyle aynıdır; donor yerine recipient kullanıcı adıyla süzülür.
+func sentDonationsHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	query, err := parseDonationHistoryQuery(c, user.Wallet.Currency)
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz filtre")
+	}
+	query.Donor = ""
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	base := bson.M{"fromUserId": user.ID}
+	if recipient := strings.ToLower(strings.TrimSpace(c.Query("recipient"))); recipient != "" {
+		var recipientUser models.User
+		err := database.Collection("users").FindOne(ctx, bson.M{"username": recipient}).Decode(&recipientUser)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return utils.Error(c, fiber.StatusNotFound, "Alıcı bulunamadı")
+		}
+		if err != nil {
+			return utils.Error(c, fiber.StatusInternalServerError, "Bağışlar yüklenemedi")
+		}
+		base["toUserId"] = recipientUser.ID
+	}
+
+	page, err := sentDonations(ctx, base, query)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağışlar yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, page)
+}
+
+func sentDonations(ctx context.Context, base bson.M, query donationHistoryQuery) (sentDonationsPage, error) {
+	page := sentDonationsPage{
+		Donations:  make([]sentDonation, 0),
+		Summary:    donationHistorySummary{Totals: []donationHistoryTotal{}},
+		Recipients: make([]recipientTotal, 0),
+	}
+
+	filter, err := historyFilter(ctx, base, query)
+	if err != nil {
+		return page, err
+	}
+
+	match := filter
+	if query.Cursor != nil {
+		condition, err := historyCursorCondition(query.Cursor)
+		if err != nil {
+			return page, err
+		}
+		match = bson.M{"$and": bson.A{filter, condition}}
+	}
+
+	cursor, err := database.Collection("donations").Aggregate(ctx, bson.A{
+		bson.M{"$match": match},
+		bson.M{"$sort": historySortStage(query.Sort)},
+		bson.M{"$limit": query.Limit + 1},
+		bson.M{"$lookup": bson.M{
+			"from":         "users",
+			"localField":   "toUserId",
+			"foreignField": "_id",
+			"as":           "toUser",
+		}},
+		bson.M{"$unwind": bson.M{"path": "$toUser", "preserveNullAndEmptyArrays": true}},
+		bson.M{"$project": bson.M{
+			"amount":            1,
+			"paidAt":            1,
+			"status":            1,
+			"message":           1,
+			"anonymous":         1,
+			"recipientName":     "$toUser.name",
+			"recipientUsername": "$toUser.username",
+		}},
+	})
+	if err != nil {
+		return page, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID                primitive.ObjectID    `bson:"_id"`
+			Amount            models.Money          `bson:"amount"`
+			PaidAt            time.Time             `bson:"paidAt"`
+			Status            models.DonationStatus `bson:"status"`
+			Message           string                `bson:"message"`
+			Anonymous         bool                  `bson:"anonymous"`
+			RecipientName     string                `bson:"recipientName"`
+			RecipientUsername string                `bson:"recipientUsername"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			continue
+		}
+
+		if len(page.Donations) == query.Limit {
+			last := page.Donations[len(page.Donations)-1]
+			page.NextCursor = encodeHistoryCursor(nextHistoryCursor(query.Sort, last.ID, last.Date, last.Amount))
+			break
+		}
+
+		page.Donations = append(page.Donations, sentDonation{
+			ID:                doc.ID.Hex(),
+			Amount:            doc.Amount,
+			Date:              doc.PaidAt,
+			Status:            doc.Status,
+			Message:           doc.Message,
+			Anonymous:         doc.Anonymous,
+			RecipientName:     doc.RecipientName,
+			RecipientUsername: doc.RecipientUsername,
+		})
+	}
+	if err := cursor.Err(); err != nil {
+		return page, err
+	}
+
+	summary, err := summarizeDonationHistory(ctx, filter)
+	if err != nil {
+		return page, err
+	}
+	page.Summary = summary
+
+	recipients, err := sumByRecipient(ctx, filter)
+	if err != nil {
+		return page, err
+	}
+	page.Recipients = recipients
+
+	return page, nil
+}
+
+// sumByRecipient, filtrelenmiş bağışları alıcı ve para birimi bazında toplar. Tutarlar farklı
+// para birimlerinde karşılaştırılamadığı için sıralama para birimi içinde, en çok ödenen alıcı
+// önce gelecek şekilde yapılır.
+func sumByRecipient(ctx context.Context, filter bson.M) ([]recipientTotal, error) {
+	paid := bson.M{"$eq": bson.A{"$status", models.DonationPaid}}
+	cursor, err := database.Collection("donations").Aggregate(ctx, bson.A{
+		bson.M{"$match": filter},
+		bson.M{"$group": bson.M{
+			"_id":           bson.M{"toUserId": "$toUserId", "currency": "$amount.currency"},
+			"count":         bson.M{"$sum": bson.M{"$cond": bson.A{paid, 1, 0}}},
+			"amount":        bson.M{"$sum": bson.M{"$cond": bson.A{paid, "$amount.minor", 0}}},
+			"reversedCount": bson.M{"$sum": bson.M{"$cond": bson.A{paid, 0, 1}}},
+			"reversed":      bson.M{"$sum": bson.M{"$cond": bson.A{paid, 0, "$amount.minor"}}},
+		}},
+		bson.M{"$sort": bson.D{{Key: "_id.currency", Value: 1}, {Key: "amount", Value: -1}, {Key: "_id.toUserId", Value: 1}}},
+		bson.M{"$limit": maxRecipientTotals},
+		bson.M{"$lookup": bson.M{
+			"from":         "users",
+			"localField":   "_id.toUserId",
+			"foreignField": "_id",
+			"as":           "toUser",
+		}},
+		bson.M{"$unwind": bson.M{"path": "$toUser", "preserveNullAndEmptyArrays": true}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	totals := make([]recipientTotal, 0)
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID struct {
+				Currency string `bson:"currency"`
+			} `bson:"_id"`
+			Count         int64 `bson:"count"`
+			Amount        int64 `bson:"amount"`
+			ReversedCount int64 `bson:"reversedCount"`
+			Reversed      int64 `bson:"reversed"`
+			ToUser        struct {
+				Name     string `bson:"name"`
+				Username string `bson:"username"`
+			} `bson:"toUser"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		totals = append(totals, recipientTotal{
+			RecipientName:     doc.ToUser.Name,
+			RecipientUsername: doc.ToUser.Username,
+			Count:             doc.Count,
+			Amount:            models.NewMoney(doc.Amount, doc.ID.Currency),
+			ReversedCount:     doc.ReversedCount,
+			Reversed:          models.NewMoney(doc.Reversed, doc.ID.Currency),
+		})
+	}
+	return totals, cursor.Err()
+}
@@ -0,0 +1,148 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"donation-app/server/database"
+	"donation-app/server/mailer"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const verificationResendCooldown = time.Minute
+
+var errVerificationThrottled = errors.New("verification mail throttled")
+
+type verifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// requestVerificationMail, bekleme süresi dolduysa doğrulama bağlantısını gönderir. Gönderim
+// zamanı koşullu güncellemeyle alındığı için eşzamanlı istekler birden fazla e-posta üretmez.
+func requestVerificationMail(ctx context.Context, user models.User) error {
+	now := time.Now().UTC()
+	update, err := database.Collection("users").UpdateOne(ctx,
+		bson.M{
+			"_id":           user.ID,
+			"emailVerified": bson.M{"$ne": true},
+			"$or": bson.A{
+				bson.M{"verificationSentAt": bson.M{"$exists": false}},
+				bson.M{"verificationSentAt": bson.M{"$lte": now.Add(-verificationResendCooldown)}},
+			},
+		},
+		bson.M{"$set": bson.M{"verificationSentAt": now}},
+	)
+	if err != nil {
+		return err
+	}
+	if update.MatchedCount == 0 {
+		return errVerificationThrottled
+	}
+
+	token, err := utils.GenerateEmailVerificationToken(user.ID.Hex(), user.Email)
+	if err != nil {
+		return err
+	}
+
+	go sendVerificationMail(user, token)
+	return nil
+}
+
+func sendVerificationMail(user models.User, token string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	link := fmt.Sprintf("%s/verify-email?token=%s", utils.FrontendURL(), url.QueryEscape(token))
+	message := mailer.Message{
+		To:      user.Email,
+		Subject: "E-posta adresinizi doğrulayın",
+		Text: fmt.Sprintf("Merhaba %s,\n\n"+
+			"Bağış alabilmek ve para çekebilmek için e-posta adresinizi doğrulamanız gerekiyor. "+
+			"Doğrulamak için aşağıdaki bağlantıyı açın:\n\n%s\n\n"+
+			"Bağlantı %d saat geçerlidir. Bu hesabı siz oluşturmadıysanız bu e-postayı yok sayabilirsiniz.\n",
+			user.Name, link, int(utils.EmailVerificationTTL/time.Hour)),
+	}
+	if err := mailer.Send(ctx, message); err != nil {
+		log.Printf("verification mail error for %s: %v", user.ID.Hex(), err)
+	}
+}
+
+func resendVerificationHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+	if user.EmailVerified {
+		return utils.Error(c, fiber.StatusConflict, "E-posta adresi zaten doğrulanmış")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err := requestVerificationMail(ctx, user)
+	if errors.Is(err, errVerificationThrottled) {
+		retryAfter := verificationResendCooldown
+		if user.VerificationSentAt != nil {
+			retryAfter = time.Until(user.VerificationSentAt.Add(verificationResendCooldown)).Round(time.Second)
+		}
+		if retryAfter < time.Second {
+			retryAfter = time.Second
+		}
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(retryAfter/time.Second)))
+		return utils.Error(c, fiber.StatusTooManyRequests, "Yeni bağlantı istemeden önce biraz bekleyin")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Doğrulama e-postası gönderilemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Doğrulama bağlantısı gönderildi"})
+}
+
+// verifyEmailHandler, imzalı bağlantıdaki adres hesabın güncel adresiyle eşleşiyorsa hesabı
+// doğrulanmış olarak işaretler. Aynı bağlantının tekrar açılması zararsızdır.
+func verifyEmailHandler(c *fiber.Ctx) error {
+	var req verifyEmailRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+
+	userHex, email, err := utils.ParseEmailVerificationToken(strings.TrimSpace(req.Token))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Doğrulama bağlantısı geçersiz veya süresi dolmuş")
+	}
+	userID, err := primitive.ObjectIDFromHex(userHex)
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Doğrulama bağlantısı geçersiz veya süresi dolmuş")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var user models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"_id": userID, "email": email}).Decode(&user); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Doğrulama bağlantısı geçersiz veya süresi dolmuş")
+	}
+
+	if !user.EmailVerified {
+		now := time.Now().UTC()
+		if _, err := database.Collection("users").UpdateOne(ctx,
+			bson.M{"_id": user.ID, "email": email, "emailVerified": bson.M{"$ne": true}},
+			bson.M{"$set": bson.M{"emailVerified": true, "emailVerifiedAt": now}},
+		); err != nil {
+			return utils.Error(c, fiber.StatusInternalServerError, "E-posta doğrulanamadı")
+		}
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "E-posta adresiniz doğrulandı"})
+}
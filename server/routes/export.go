@@ -0,0 +1,231 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"donation-app/server/ledger"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	exportFormatCSV    = "csv"
+	exportFormatNDJSON = "ndjson"
+	exportTimeout      = 2 * time.Minute
+	utf8BOM            = "\ufeff"
+)
+
+// Sütun adları dışa aktarılan dosyaların sözleşmesidir; yeniden adlandırılmamalı, yeni
+// sütunlar yalnızca sona eklenmelidir.
+var exportColumns = []string{
+	"entry_id", "date", "type", "reference", "description",
+	"gross", "platform_fee", "processor_fee", "amount", "balance", "currency",
+}
+
+type exportRow struct {
+	EntryID      string `json:"entry_id"`
+	Date         string `json:"date"`
+	Type         string `json:"type"`
+	Reference    string `json:"reference"`
+	Description  string `json:"description"`
+	Gross        string `json:"gross"`
+	PlatformFee  string `json:"platform_fee"`
+	ProcessorFee string `json:"processor_fee"`
+	Amount       string `json:"amount"`
+	Balance      string `json:"balance"`
+	Currency     string `json:"currency"`
+}
+
+func (r exportRow) values() []string {
+	return []string{
+		r.EntryID, r.Date, r.Type, r.Reference, r.Description,
+		r.Gross, r.PlatformFee, r.ProcessorFee, r.Amount, r.Balance, r.Currency,
+	}
+}
+
+// walletExportHandler, cüzdan hesabına dokunan tüm ledger kayıtlarını (bağışlar, komisyonlar,
+// para çekmeler, iadeler, düzeltmeler) seçilen aralıkta yürüyen bakiyeyle birlikte akıtır.
+func walletExportHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	format := c.Query("format", exportFormatCSV)
+	if format != exportFormatCSV && format != exportFormatNDJSON {
+		return utils.Error(c, fiber.StatusBadRequest, "Desteklenmeyen dosya biçimi")
+	}
+
+	var from, to time.Time
+	if raw := c.Query("from"); raw != "" {
+		parsed, err := parseHistoryDate(raw, false)
+		if err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçersiz başlangıç tarihi")
+		}
+		from = parsed
+	}
+	if raw := c.Query("to"); raw != "" {
+		parsed, err := parseHistoryDate(raw, true)
+		if err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçersiz bitiş tarihi")
+		}
+		to = parsed.Add(time.Nanosecond)
+	}
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return utils.Error(c, fiber.StatusBadRequest, "Bitiş tarihi başlangıçtan sonra olmalı")
+	}
+
+	currency := user.Wallet.Currency
+	if raw := c.Query("currency"); raw != "" {
+		normalized, err := models.NormalizeCurrency(raw)
+		if err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Desteklenmeyen para birimi")
+		}
+		currency = normalized
+	}
+
+	account := ledger.WalletAccount(user.ID)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opening := models.Zero(currency)
+	if !from.IsZero() {
+		balance, err := ledger.BalanceBefore(ctx, account, currency, from)
+		if err != nil {
+			return utils.Error(c, fiber.StatusInternalServerError, "Döküm oluşturulamadı")
+		}
+		opening = balance
+	}
+
+	filename := fmt.Sprintf("wallet-%s-%s", user.Username, exportRangeLabel(from, to))
+	if format == exportFormatCSV {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+	} else {
+		c.Set(fiber.HeaderContentType, "application/x-ndjson; charset=utf-8")
+		c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s.ndjson"`, filename))
+	}
+	withBOM := format == exportFormatCSV && c.QueryBool("bom", false)
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+
+		if err := writeWalletExport(streamCtx, w, format, withBOM, account, currency, from, to, opening); err != nil {
+			log.Printf("wallet export error for %s: %v", user.ID.Hex(), err)
+		}
+		_ = w.Flush()
+	})
+
+	return nil
+}
+
+func writeWalletExport(ctx context.Context, w *bufio.Writer, format string, withBOM bool, account ledger.Account, currency string, from, to time.Time, opening models.Money) error {
+	cursor, err := ledger.Entries(ctx, account, currency, from, to)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var csvWriter *csv.Writer
+	encoder := json.NewEncoder(w)
+	if format == exportFormatCSV {
+		if withBOM {
+			if _, err := w.WriteString(utf8BOM); err != nil {
+				return err
+			}
+		}
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(exportColumns); err != nil {
+			return err
+		}
+	}
+
+	write := func(row exportRow) error {
+		if csvWriter != nil {
+			if err := csvWriter.Write(row.values()); err != nil {
+				return err
+			}
+			csvWriter.Flush()
+			return csvWriter.Error()
+		}
+		return encoder.Encode(row)
+	}
+
+	balance := opening
+	if err := write(exportRow{
+		Date:        exportTimestamp(from),
+		Type:        "opening_balance",
+		Description: "opening balance",
+		Amount:      models.Zero(currency).Decimal(),
+		Balance:     balance.Decimal(),
+		Currency:    currency,
+	}); err != nil {
+		return err
+	}
+
+	for cursor.Next(ctx) {
+		var entry ledger.Entry
+		if err := cursor.Decode(&entry); err != nil {
+			return err
+		}
+
+		amount := entry.Net(account)
+		balance.Minor += amount.Minor
+
+		row := exportRow{
+			EntryID:     entry.ID.Hex(),
+			Date:        entry.CreatedAt.UTC().Format(time.RFC3339),
+			Type:        string(entry.Kind),
+			Reference:   entry.Reference,
+			Description: entry.Memo,
+			Amount:      amount.Decimal(),
+			Balance:     balance.Decimal(),
+			Currency:    currency,
+		}
+		// Bağış ve iade kayıtları brüt tutarı ve komisyonları da taşır; diğer hareketlerde boş kalır.
+		if entry.Kind == ledger.KindDonation || entry.Kind == ledger.KindRefund {
+			gross := entry.Net(ledger.AccountClearing).Neg()
+			if !gross.IsZero() {
+				row.Gross = gross.Decimal()
+				row.PlatformFee = entry.Net(ledger.AccountFees).Decimal()
+				row.ProcessorFee = entry.Net(ledger.AccountProcessorFees).Decimal()
+			}
+		}
+
+		if err := write(row); err != nil {
+			return err
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func exportRangeLabel(from, to time.Time) string {
+	start, end := "all", "now"
+	if !from.IsZero() {
+		start = from.Format("20060102")
+	}
+	if !to.IsZero() {
+		end = to.Add(-time.Nanosecond).Format("20060102")
+	}
+	return start + "-" + end
+}
+
+func exportTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,417 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/events"
+	"donation-app/server/middleware"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const goalsEventType = "goals"
+
+type goalRequest struct {
+	Title        *string      `json:"title"`
+	Target       *json.Number `json:"target"`
+	StartAt      *time.Time   `json:"startAt"`
+	EndAt        *time.Time   `json:"endAt"`
+	ClearEndAt   bool         `json:"clearEndAt"`
+	ResetOnReach *bool        `json:"resetOnReach"`
+}
+
+func RegisterGoalRoutes(router fiber.Router) {
+	protected := router.Group("", middleware.Protected())
+	protected.Get("/", listGoalsHandler)
+	protected.Post("/", createGoalHandler)
+	protected.Put("/:id", updateGoalHandler)
+	protected.Delete("/:id", archiveGoalHandler)
+}
+
+func listGoalsHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := database.Collection("goals").Find(ctx,
+		bson.M{"userId": user.ID, "archivedAt": bson.M{"$exists": false}},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedefler yüklenemedi")
+	}
+	defer cursor.Close(ctx)
+
+	var goals []models.Goal
+	if err := cursor.All(ctx, &goals); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedefler yüklenemedi")
+	}
+
+	progress, err := goalsProgress(ctx, goals)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedefler yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"goals": progress})
+}
+
+func createGoalHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	var req goalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+	if req.Title == nil || req.Target == nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Başlık ve hedef tutar zorunludur")
+	}
+
+	now := time.Now().UTC()
+	goal := models.Goal{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		StartAt:   now,
+		CreatedAt: now,
+	}
+	if message := applyGoalRequest(&goal, req, user.Wallet.Currency); message != "" {
+		return utils.Error(c, fiber.StatusBadRequest, message)
+	}
+	goal.CycleStartedAt = goal.StartAt
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := database.Collection("goals").CountDocuments(ctx, bson.M{"userId": user.ID, "archivedAt": bson.M{"$exists": false}})
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef oluşturulamadı")
+	}
+	if count >= models.MaxGoalsPerUser {
+		return utils.Error(c, fiber.StatusBadRequest, fmt.Sprintf("En fazla %d hedef oluşturabilirsiniz", models.MaxGoalsPerUser))
+	}
+
+	if _, err := database.Collection("goals").InsertOne(ctx, goal); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef oluşturulamadı")
+	}
+
+	progress, err := goalsProgress(ctx, []models.Goal{goal})
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef oluşturulamadı")
+	}
+	publishGoals(ctx, user.ID)
+
+	return utils.Success(c, fiber.StatusCreated, fiber.Map{"goal": progress[0]})
+}
+
+func updateGoalHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	goalID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz hedef kimliği")
+	}
+
+	var req goalRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	filter := bson.M{"_id": goalID, "userId": user.ID, "archivedAt": bson.M{"$exists": false}}
+	var goal models.Goal
+	if err := database.Collection("goals").FindOne(ctx, filter).Decode(&goal); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return utils.Error(c, fiber.StatusNotFound, "Hedef bulunamadı")
+		}
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef güncellenemedi")
+	}
+
+	if message := applyGoalRequest(&goal, req, goal.Target.Currency); message != "" {
+		return utils.Error(c, fiber.StatusBadRequest, message)
+	}
+
+	if _, err := database.Collection("goals").ReplaceOne(ctx, filter, goal); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef güncellenemedi")
+	}
+
+	progress, err := goalsProgress(ctx, []models.Goal{goal})
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef güncellenemedi")
+	}
+	publishGoals(ctx, user.ID)
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"goal": progress[0]})
+}
+
+func archiveGoalHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	goalID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz hedef kimliği")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := database.Collection("goals").UpdateOne(ctx,
+		bson.M{"_id": goalID, "userId": user.ID, "archivedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"archivedAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedef silinemedi")
+	}
+	if result.MatchedCount == 0 {
+		return utils.Error(c, fiber.StatusNotFound, "Hedef bulunamadı")
+	}
+	publishGoals(ctx, user.ID)
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Hedef silindi"})
+}
+
+// applyGoalRequest, istekteki alanları hedefe uygular ve hata durumunda kullanıcıya
+// gösterilecek mesajı döndürür.
+func applyGoalRequest(goal *models.Goal, req goalRequest, currency string) string {
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" || utf8.RuneCountInString(title) > models.MaxGoalTitleLength {
+			return fmt.Sprintf("Başlık 1 ile %d karakter arasında olmalı", models.MaxGoalTitleLength)
+		}
+		goal.Title = title
+	}
+	if req.Target != nil {
+		target, err := models.ParseMoney(req.Target.String(), currency)
+		if err != nil || !target.IsPositive() {
+			return "Geçersiz hedef tutarı"
+		}
+		goal.Target = target
+	}
+	if req.StartAt != nil {
+		start := req.StartAt.UTC()
+		if goal.CycleStartedAt.Equal(goal.StartAt) || goal.CycleStartedAt.Before(start) {
+			goal.CycleStartedAt = start
+		}
+		goal.StartAt = start
+	}
+	if req.ClearEndAt {
+		goal.EndAt = nil
+	} else if req.EndAt != nil {
+		end := req.EndAt.UTC()
+		goal.EndAt = &end
+	}
+	if goal.EndAt != nil && !goal.EndAt.After(goal.StartAt) {
+		return "Bitiş tarihi başlangıçtan sonra olmalı"
+	}
+	if req.ResetOnReach != nil {
+		goal.ResetOnReach = *req.ResetOnReach
+	}
+	return ""
+}
+
+// goalsProgress, her hedef için penceresindeki ödenmiş bağışları toplar. İade edilen ve ters
+// ibraz edilen bağışlar paid durumundan çıktığı için ilerlemeden kendiliğinden düşer.
+func goalsProgress(ctx context.Context, goals []models.Goal) ([]models.GoalProgress, error) {
+	progress := make([]models.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		paidAt := bson.M{"$gte": goal.ProgressWindowStart()}
+		if goal.EndAt != nil {
+			paidAt["$lt"] = *goal.EndAt
+		}
+
+		cursor, err := database.Collection("donations").Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$match", Value: bson.M{
+				"toUserId":        goal.UserID,
+				"status":          models.DonationPaid,
+				"paidAt":          paidAt,
+				"amount.currency": goal.Target.Currency,
+			}}},
+			{{Key: "$group", Value: bson.M{"_id": nil, "raised": bson.M{"$sum": "$amount.minor"}}}},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var totals []struct {
+			Raised int64 `bson:"raised"`
+		}
+		err = cursor.All(ctx, &totals)
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		raised := models.Zero(goal.Target.Currency)
+		if len(totals) > 0 {
+			raised.Minor = totals[0].Raised
+		}
+
+		percent := 0
+		if goal.Target.Minor > 0 {
+			percent = int(raised.Minor * 100 / goal.Target.Minor)
+		}
+		if percent > 100 {
+			percent = 100
+		}
+
+		progress = append(progress, models.GoalProgress{
+			Goal:    goal,
+			Raised:  raised,
+			Percent: percent,
+			Reached: raised.Minor >= goal.Target.Minor,
+		})
+	}
+	return progress, nil
+}
+
+func activeGoals(ctx context.Context, creatorID primitive.ObjectID) ([]models.Goal, error) {
+	now := time.Now().UTC()
+	cursor, err := database.Collection("goals").Find(ctx,
+		bson.M{
+			"userId":     creatorID,
+			"archivedAt": bson.M{"$exists": false},
+			"startAt":    bson.M{"$lte": now},
+			"$or": bson.A{
+				bson.M{"endAt": bson.M{"$exists": false}},
+				bson.M{"endAt": bson.M{"$gt": now}},
+			},
+		},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	goals := make([]models.Goal, 0)
+	if err := cursor.All(ctx, &goals); err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+func activeGoalsProgress(ctx context.Context, creatorID primitive.ObjectID) ([]models.GoalProgress, error) {
+	goals, err := activeGoals(ctx, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	return goalsProgress(ctx, goals)
+}
+
+// refreshGoals, bağış durumu değiştiğinde hedefleri yeniden hesaplar. Hedefe ulaşan ve
+// sıfırlanan hedeflerde önce dolu hâl yayınlanır, ardından yeni tur başlatılır; fazla tutar
+// yeni tura aktarılmaz.
+func refreshGoals(ctx context.Context, creatorID primitive.ObjectID) error {
+	progress, err := activeGoalsProgress(ctx, creatorID)
+	if err != nil {
+		return err
+	}
+	if len(progress) == 0 {
+		return nil
+	}
+
+	reset := false
+	for _, goal := range progress {
+		if !goal.ResetOnReach || !goal.Reached {
+			continue
+		}
+		_, err := database.Collection("goals").UpdateOne(ctx,
+			bson.M{"_id": goal.ID, "cycleStartedAt": goal.CycleStartedAt},
+			bson.M{"$set": bson.M{"cycleStartedAt": time.Now().UTC()}, "$inc": bson.M{"completions": 1}},
+		)
+		if err != nil {
+			return err
+		}
+		reset = true
+	}
+
+	publishGoalEvent(creatorID, progress)
+	if reset {
+		publishGoals(ctx, creatorID)
+	}
+	return nil
+}
+
+func publishGoals(ctx context.Context, creatorID primitive.ObjectID) {
+	progress, err := activeGoalsProgress(ctx, creatorID)
+	if err != nil {
+		log.Printf("goal progress error for %s: %v", creatorID.Hex(), err)
+		return
+	}
+	publishGoalEvent(creatorID, progress)
+}
+
+func publishGoalEvent(creatorID primitive.ObjectID, progress []models.GoalProgress) {
+	events.Publish(creatorID, goalsEvent(progress))
+}
+
+func goalsEvent(progress []models.GoalProgress) events.Event {
+	if progress == nil {
+		progress = []models.GoalProgress{}
+	}
+	return events.Event{
+		ID:   strconv.FormatInt(time.Now().UnixNano(), 10),
+		Type: goalsEventType,
+		Data: fiber.Map{"goals": progress},
+	}
+}
+
+func overlayGoalsHandler(c *fiber.Ctx) error {
+	key, ok := c.Locals("overlayKey").(models.OverlayKey)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Overlay anahtarı gerekli")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	progress, err := activeGoalsProgress(ctx, key.UserID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedefler yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"goals": progress})
+}
+
+// overlayGoalsStreamHandler, bağlanınca güncel ilerlemeyi, sonra her değişikliği gönderir.
+func overlayGoalsStreamHandler(c *fiber.Ctx) error {
+	key, ok := c.Locals("overlayKey").(models.OverlayKey)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Overlay anahtarı gerekli")
+	}
+
+	return streamCreatorEvents(c, key, goalsEventType, func(ctx context.Context) ([]events.Event, error) {
+		progress, err := activeGoalsProgress(ctx, key.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return []events.Event{goalsEvent(progress)}, nil
+	})
+}
@@ -0,0 +1,241 @@
+package routes
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/events"
+	"donation-app/server/middleware"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	sseKeepAliveInterval = 25 * time.Second
+	sseReplayLimit       = 50
+	donationEventType    = "donation"
+)
+
+type overlayAlert struct {
+	ID           string       `json:"id"`
+	Amount       models.Money `json:"amount"`
+	FromUserName string       `json:"fromUserName"`
+	DisplayName  string       `json:"displayName,omitempty"`
+	Message      string       `json:"message,omitempty"`
+	Date         time.Time    `json:"date"`
+}
+
+// Anahtar yetkisi rota bazında eklenir; ön eki boş bir grup ara katmanı tüm /overlay
+// rotalarına uygulardı ve goals anahtarları hiçbir widget'a erişemezdi.
+func RegisterOverlayRoutes(router fiber.Router) {
+	alerts := middleware.OverlayKey(models.OverlayScopeAlerts)
+	router.Get("/stream", alerts, overlayStreamHandler)
+	router.Get("/donations", alerts, overlayDonationsHandler)
+
+	goals := middleware.OverlayKey(models.OverlayScopeGoals)
+	router.Get("/goals", goals, overlayGoalsHandler)
+	router.Get("/goals/stream", goals, overlayGoalsStreamHandler)
+}
+
+func newOverlayAlert(donation models.Donation) overlayAlert {
+	name := donation.DonorName
+	if donation.Anonymous {
+		name = anonymousDonorName
+	}
+
+	date := donation.Date
+	if donation.PaidAt != nil {
+		date = *donation.PaidAt
+	}
+
+	alert := overlayAlert{
+		ID:           donation.ID.Hex(),
+		Amount:       donation.Amount,
+		FromUserName: name,
+		Date:         date,
+	}
+	if donation.MessagePublic() {
+		alert.DisplayName = donation.DisplayName
+		alert.Message = donation.Message
+	}
+	return alert
+}
+
+// reviewAlert, onay bekleyen bağışı panelde moderatöre mesajıyla birlikte göstermek içindir;
+// overlay'e giden yüklerde kullanılmamalıdır.
+func reviewAlert(donation models.Donation) overlayAlert {
+	alert := newOverlayAlert(donation)
+	alert.DisplayName = donation.DisplayName
+	alert.Message = donation.Message
+	return alert
+}
+
+func donationPaidEvent(donation models.Donation) events.Event {
+	return events.Event{ID: donation.ID.Hex(), Type: donationEventType, Data: newOverlayAlert(donation), Revision: string(donation.MessageReview)}
+}
+
+func overlayStreamHandler(c *fiber.Ctx) error {
+	key, ok := c.Locals("overlayKey").(models.OverlayKey)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Overlay anahtarı gerekli")
+	}
+
+	lastEventID := strings.TrimSpace(c.Get("Last-Event-ID"))
+	if lastEventID == "" {
+		lastEventID = strings.TrimSpace(c.Query("lastEventId"))
+	}
+
+	return streamCreatorEvents(c, key, donationEventType, func(ctx context.Context) ([]events.Event, error) {
+		return replayDonationEvents(ctx, key.UserID, lastEventID)
+	})
+}
+
+func overlayDonationsHandler(c *fiber.Ctx) error {
+	key, ok := c.Locals("overlayKey").(models.OverlayKey)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Overlay anahtarı gerekli")
+	}
+
+	return selectedDonations(c, key.UserID)
+}
+
+// streamCreatorEvents, önce canlı yayına abone olur, sonra initial ile başlangıç olaylarını
+// (ör. Last-Event-ID'den sonraki ödenmiş bağışlar) gönderir; böylece yeniden bağlanma
+// sırasında gelen olaylar kaybolmaz. Yalnızca eventType türündeki olaylar iletilir; anahtar
+// iptal edilir ya da yenilenirse akış kapanır.
+func streamCreatorEvents(c *fiber.Ctx, key models.OverlayKey, eventType string, initial func(ctx context.Context) ([]events.Event, error)) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	subscription, unsubscribe := events.Subscribe(key.UserID)
+
+	replay, err := initial(ctx)
+	if err != nil {
+		unsubscribe()
+		return utils.Error(c, fiber.StatusInternalServerError, "Olaylar yüklenemedi")
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		fmt.Fprint(w, "retry: 3000\n\n")
+		sent := make(map[string]struct{}, len(replay))
+		for _, event := range replay {
+			sent[sseDedupeKey(event)] = struct{}{}
+			if err := writeSSE(w, event); err != nil {
+				return
+			}
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+
+		keepAlive := time.NewTicker(sseKeepAliveInterval)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case event, ok := <-subscription:
+				if !ok || isOverlayKeyRevokedEvent(event, key.ID) {
+					return
+				}
+				if event.Type != eventType {
+					continue
+				}
+				if _, dup := sent[sseDedupeKey(event)]; dup {
+					continue
+				}
+				if err := writeSSE(w, event); err != nil {
+					return
+				}
+			case <-keepAlive.C:
+				if !overlayKeyStillActive(key) {
+					return
+				}
+				if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	})
+
+	return nil
+}
+
+// sseDedupeKey, onaylanan mesajla yeniden yayınlanan bağışın önceki gönderimle
+// karıştırılmaması için kimliğe sürümü de ekler.
+func sseDedupeKey(event events.Event) string {
+	return event.ID + "#" + event.Revision
+}
+
+func writeSSE(w *bufio.Writer, event events.Event) error {
+	payload, err := json.Marshal(event.Data)
+	if err != nil {
+		log.Printf("sse marshal error: %v", err)
+		return nil
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, payload)
+	return err
+}
+
+func replayDonationEvents(ctx context.Context, creatorID primitive.ObjectID, lastEventID string) ([]events.Event, error) {
+	if lastEventID == "" {
+		return nil, nil
+	}
+
+	lastID, err := primitive.ObjectIDFromHex(lastEventID)
+	if err != nil {
+		return nil, nil
+	}
+
+	var last models.Donation
+	if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": lastID, "toUserId": creatorID}).Decode(&last); err != nil || last.PaidAt == nil {
+		return nil, nil
+	}
+
+	cursor, err := database.Collection("donations").Find(ctx,
+		bson.M{
+			"toUserId": creatorID,
+			"status":   models.DonationPaid,
+			"$or": bson.A{
+				bson.M{"paidAt": bson.M{"$gt": *last.PaidAt}},
+				bson.M{"paidAt": *last.PaidAt, "_id": bson.M{"$gt": last.ID}},
+			},
+		},
+		options.Find().SetSort(bson.D{{Key: "paidAt", Value: 1}, {Key: "_id", Value: 1}}).SetLimit(sseReplayLimit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var donations []models.Donation
+	if err := cursor.All(ctx, &donations); err != nil {
+		return nil, err
+	}
+
+	replay := make([]events.Event, 0, len(donations))
+	for _, donation := range donations {
+		replay = append(replay, donationPaidEvent(donation))
+	}
+	return replay, nil
+}
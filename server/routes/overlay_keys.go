@@ -0,0 +1,258 @@
+package routes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/events"
+	"donation-app/server/middleware"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	overlayKeyPrefix      = "ovk_"
+	overlayKeyBytes       = 32
+	maxOverlayKeysPerUser = 20
+
+	overlayKeyRevokedEventType = "overlay_key_revoked"
+)
+
+type createOverlayKeyRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type overlayKeyResponse struct {
+	models.OverlayKey
+	Key string `json:"key,omitempty"`
+}
+
+func RegisterOverlayKeyRoutes(router fiber.Router) {
+	protected := router.Group("", middleware.Protected())
+	protected.Get("/", listOverlayKeysHandler)
+	protected.Post("/", createOverlayKeyHandler)
+	protected.Post("/:id/rotate", rotateOverlayKeyHandler)
+	protected.Delete("/:id", revokeOverlayKeyHandler)
+}
+
+func listOverlayKeysHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := database.Collection("overlay_keys").Find(ctx,
+		bson.M{"userId": user.ID, "revokedAt": bson.M{"$exists": false}},
+		options.Find().SetSort(bson.D{{Key: "createdAt", Value: -1}}),
+	)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarları yüklenemedi")
+	}
+	defer cursor.Close(ctx)
+
+	keys := make([]models.OverlayKey, 0)
+	if err := cursor.All(ctx, &keys); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarları yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"keys": keys})
+}
+
+func createOverlayKeyHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	var req createOverlayKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+
+	scopes, ok := normalizeOverlayScopes(req.Scopes)
+	if !ok {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz widget yetkisi")
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = "OBS"
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := database.Collection("overlay_keys").CountDocuments(ctx, bson.M{"userId": user.ID, "revokedAt": bson.M{"$exists": false}})
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarı oluşturulamadı")
+	}
+	if count >= maxOverlayKeysPerUser {
+		return utils.Error(c, fiber.StatusBadRequest, "Çok fazla aktif overlay anahtarı var")
+	}
+
+	key, plain, err := newOverlayKey(user.ID, name, scopes)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarı oluşturulamadı")
+	}
+
+	if _, err := database.Collection("overlay_keys").InsertOne(ctx, key); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarı oluşturulamadı")
+	}
+
+	return utils.Success(c, fiber.StatusCreated, fiber.Map{"key": overlayKeyResponse{OverlayKey: key, Key: plain}})
+}
+
+// rotateOverlayKeyHandler, eski anahtarı iptal edip aynı ad ve yetkilerle yenisini üretir.
+func rotateOverlayKeyHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	keyID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz anahtar kimliği")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var rotated models.OverlayKey
+	var plain string
+	err = database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var old models.OverlayKey
+		if err := database.Collection("overlay_keys").FindOneAndUpdate(sessCtx,
+			bson.M{"_id": keyID, "userId": user.ID, "revokedAt": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}},
+		).Decode(&old); err != nil {
+			return err
+		}
+
+		var err error
+		rotated, plain, err = newOverlayKey(user.ID, old.Name, old.Scopes)
+		if err != nil {
+			return err
+		}
+		_, err = database.Collection("overlay_keys").InsertOne(sessCtx, rotated)
+		return err
+	})
+	if err == mongo.ErrNoDocuments {
+		return utils.Error(c, fiber.StatusNotFound, "Overlay anahtarı bulunamadı")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarı yenilenemedi")
+	}
+	publishOverlayKeyRevoked(user.ID, keyID)
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"key": overlayKeyResponse{OverlayKey: rotated, Key: plain}})
+}
+
+func revokeOverlayKeyHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	keyID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz anahtar kimliği")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := database.Collection("overlay_keys").UpdateOne(ctx,
+		bson.M{"_id": keyID, "userId": user.ID, "revokedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revokedAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Overlay anahtarı iptal edilemedi")
+	}
+	if result.MatchedCount == 0 {
+		return utils.Error(c, fiber.StatusNotFound, "Overlay anahtarı bulunamadı")
+	}
+	publishOverlayKeyRevoked(user.ID, keyID)
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Overlay anahtarı iptal edildi"})
+}
+
+// publishOverlayKeyRevoked, anahtarla açılmış akışların kapanması için yayın yapar.
+func publishOverlayKeyRevoked(userID primitive.ObjectID, keyID primitive.ObjectID) {
+	events.Publish(userID, events.Event{ID: keyID.Hex(), Type: overlayKeyRevokedEventType})
+}
+
+func isOverlayKeyRevokedEvent(event events.Event, keyID primitive.ObjectID) bool {
+	return event.Type == overlayKeyRevokedEventType && event.ID == keyID.Hex()
+}
+
+// overlayKeyStillActive, yayın kaçırılmış olabileceği için (dolu abone tamponu) açık
+// akışların anahtarı periyodik olarak yeniden doğrulamasına yarar. Veritabanı hatasında
+// bağlantı kesilmez; bir sonraki denetim karar verir.
+func overlayKeyStillActive(key models.OverlayKey) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := database.Collection("overlay_keys").CountDocuments(ctx, bson.M{
+		"_id":       key.ID,
+		"keyHash":   key.KeyHash,
+		"revokedAt": bson.M{"$exists": false},
+	})
+	return err != nil || count > 0
+}
+
+func newOverlayKey(userID primitive.ObjectID, name string, scopes []string) (models.OverlayKey, string, error) {
+	secret, err := utils.RandomToken(overlayKeyBytes)
+	if err != nil {
+		return models.OverlayKey{}, "", err
+	}
+	plain := overlayKeyPrefix + secret
+
+	return models.OverlayKey{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		Name:      name,
+		Prefix:    plain[:len(overlayKeyPrefix)+6],
+		KeyHash:   utils.HashToken(plain),
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+	}, plain, nil
+}
+
+func normalizeOverlayScopes(requested []string) ([]string, bool) {
+	if len(requested) == 0 {
+		return append([]string(nil), models.OverlayScopes...), true
+	}
+
+	scopes := make([]string, 0, len(requested))
+	seen := make(map[string]struct{}, len(requested))
+	for _, scope := range requested {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		valid := false
+		for _, allowed := range models.OverlayScopes {
+			if scope == allowed {
+				valid = true
+			}
+		}
+		if !valid {
+			return nil, false
+		}
+		if _, dup := seen[scope]; dup {
+			continue
+		}
+		seen[scope] = struct{}{}
+		scopes = append(scopes, scope)
+	}
+	return scopes, true
+}
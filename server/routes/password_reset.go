@@ -0,0 +1,202 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/mailer"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const (
+	passwordResetTTL      = time.Hour
+	passwordResetCooldown = time.Minute
+	passwordResetBytes    = 32
+	mailSendTimeout       = 30 * time.Second
+)
+
+var errPasswordResetInvalid = errors.New("password reset token invalid")
+
+type forgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// forgotPasswordHandler, hesabın var olup olmadığını belli etmemek için her durumda aynı yanıtı verir.
+func forgotPasswordHandler(c *fiber.Ctx) error {
+	var req forgotPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+	email := strings.TrimSpace(strings.ToLower(req.Email))
+	if email == "" {
+		return utils.Error(c, fiber.StatusBadRequest, "E-posta gerekli")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	accepted := fiber.Map{"message": "Bu e-posta kayıtlıysa şifre sıfırlama bağlantısı gönderildi"}
+
+	var user models.User
+	err := database.Collection("users").FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return utils.Success(c, fiber.StatusOK, accepted)
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "İstek işlenemedi")
+	}
+
+	plain, err := createPasswordReset(ctx, user.ID, c.IP())
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "İstek işlenemedi")
+	}
+	if plain == "" {
+		return utils.Success(c, fiber.StatusOK, accepted)
+	}
+
+	go sendPasswordResetMail(user, plain)
+
+	return utils.Success(c, fiber.StatusOK, accepted)
+}
+
+// createPasswordReset, kullanıcının açık sıfırlama belirteçlerini geçersiz kılıp yenisini üretir.
+// Bekleme süresi dolmadan gelen isteklerde boş değer döner.
+func createPasswordReset(ctx context.Context, userID primitive.ObjectID, ip string) (string, error) {
+	resets := database.Collection("password_resets")
+	now := time.Now().UTC()
+
+	recent, err := resets.CountDocuments(ctx, bson.M{"userId": userID, "createdAt": bson.M{"$gt": now.Add(-passwordResetCooldown)}})
+	if err != nil {
+		return "", err
+	}
+	if recent > 0 {
+		return "", nil
+	}
+
+	plain, err := utils.RandomToken(passwordResetBytes)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := resets.UpdateMany(ctx,
+		bson.M{"userId": userID, "usedAt": bson.M{"$exists": false}, "invalidatedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"invalidatedAt": now}},
+	); err != nil {
+		return "", err
+	}
+
+	reset := models.PasswordReset{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		TokenHash: utils.HashToken(plain),
+		IP:        ip,
+		CreatedAt: now,
+		ExpiresAt: now.Add(passwordResetTTL),
+	}
+	if _, err := resets.InsertOne(ctx, reset); err != nil {
+		return "", err
+	}
+	return plain, nil
+}
+
+func sendPasswordResetMail(user models.User, plain string) {
+	ctx, cancel := context.WithTimeout(context.Background(), mailSendTimeout)
+	defer cancel()
+
+	link := fmt.Sprintf("%s/reset-password?token=%s", utils.FrontendURL(), url.QueryEscape(plain))
+	message := mailer.Message{
+		To:      user.Email,
+		Subject: "Şifre sıfırlama",
+		Text: fmt.Sprintf("Merhaba %s,\n\n"+
+			"Hesabınız için şifre sıfırlama isteği aldık. Yeni şifrenizi belirlemek için aşağıdaki bağlantıyı açın:\n\n%s\n\n"+
+			"Bağlantı %d dakika geçerlidir ve yalnızca bir kez kullanılabilir. Şifreniz değiştiğinde tüm cihazlardaki oturumlar kapatılır.\n\n"+
+			"Bu isteği siz yapmadıysanız bu e-postayı yok sayabilirsiniz.\n",
+			user.Name, link, int(passwordResetTTL/time.Minute)),
+	}
+	if err := mailer.Send(ctx, message); err != nil {
+		log.Printf("password reset mail error for %s: %v", user.ID.Hex(), err)
+	}
+}
+
+// resetPasswordHandler, belirteci tüketip şifreyi değiştirir ve kullanıcının bütün oturumlarını kapatır.
+func resetPasswordHandler(c *fiber.Ctx) error {
+	var req resetPasswordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+	plain := strings.TrimSpace(req.Token)
+	if plain == "" {
+		return utils.Error(c, fiber.StatusBadRequest, "Sıfırlama bağlantısı geçersiz veya süresi dolmuş")
+	}
+	if len(req.Password) < 6 {
+		return utils.Error(c, fiber.StatusBadRequest, "Şifre en az 6 karakter olmalı")
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Şifre oluşturulamadı")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	err = database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		now := time.Now().UTC()
+
+		var reset models.PasswordReset
+		err := database.Collection("password_resets").FindOneAndUpdate(sessCtx,
+			bson.M{
+				"tokenHash":     utils.HashToken(plain),
+				"usedAt":        bson.M{"$exists": false},
+				"invalidatedAt": bson.M{"$exists": false},
+				"expiresAt":     bson.M{"$gt": now},
+			},
+			bson.M{"$set": bson.M{"usedAt": now}},
+		).Decode(&reset)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return errPasswordResetInvalid
+		}
+		if err != nil {
+			return err
+		}
+
+		update, err := database.Collection("users").UpdateOne(sessCtx,
+			bson.M{"_id": reset.UserID},
+			bson.M{"$set": bson.M{"passwordHash": hash, "passwordChangedAt": now}},
+		)
+		if err != nil {
+			return err
+		}
+		if update.MatchedCount == 0 {
+			return errPasswordResetInvalid
+		}
+
+		_, err = revokeUserSessions(sessCtx, reset.UserID, primitive.NilObjectID, "password_reset")
+		return err
+	})
+	if errors.Is(err, errPasswordResetInvalid) {
+		return utils.Error(c, fiber.StatusBadRequest, "Sıfırlama bağlantısı geçersiz veya süresi dolmuş")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Şifre güncellenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Şifreniz güncellendi, lütfen tekrar giriş yapın"})
+}
@@ -0,0 +1,133 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/payments"
+)
+
+var errAmountMismatch = errors.New("paid amount does not match donation")
+
+func RegisterPaymentRoutes(router fiber.Router) {
+	router.Post("/:provider/callback", paymentCallbackHandler)
+	registerMockPaymentRoutes(router)
+}
+
+// Sağlayıcı bildirimleri sunucudan sunucuya gelir; kimlik doğrulaması imza ile yapılır.
+func paymentCallbackHandler(c *fiber.Ctx) error {
+	provider, ok := payments.Get(c.Params("provider"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).SendString("unknown provider")
+	}
+
+	form := url.Values{}
+	c.Request().PostArgs().VisitAll(func(key, value []byte) {
+		form.Add(string(key), string(value))
+	})
+
+	result, err := provider.VerifyCallback(form)
+	if err != nil {
+		log.Printf("payment callback rejected (%s): %v", provider.Name(), err)
+		return c.Status(fiber.StatusBadRequest).SendString("invalid notification")
+	}
+
+	donationID, err := primitive.ObjectIDFromHex(result.OrderID)
+	if err != nil {
+		log.Printf("payment callback for unknown order %q", result.OrderID)
+		return c.SendString(provider.CallbackAck())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	settle := settleDonation
+	if result.Status == payments.CallbackChargedBack {
+		settle = chargeBackDonation
+	}
+
+	if err := settle(ctx, donationID, result); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			log.Printf("payment callback for missing donation %s", result.OrderID)
+			return c.SendString(provider.CallbackAck())
+		}
+		log.Printf("payment callback settle error (%s): %v", result.OrderID, err)
+		return c.Status(fiber.StatusInternalServerError).SendString("retry")
+	}
+
+	return c.SendString(provider.CallbackAck())
+}
+
+// chargeBackDonation, sağlayıcının bildirdiği ters ibrazı kaydeder. Bağış ödenmiş değilse
+// (ör. zaten ters ibraz edilmiş ya da iade edilmiş) bildirim yalnızca günlüğe yazılır.
+func chargeBackDonation(ctx context.Context, donationID primitive.ObjectID, result payments.CallbackResult) error {
+	_, _, err := transitionDonation(ctx, donationID, donationTransition{
+		To:     models.DonationChargedBack,
+		Reason: "chargeback notified by provider",
+	})
+	if errors.Is(err, models.ErrInvalidTransition) {
+		log.Printf("chargeback notification ignored for donation %s", donationID.Hex())
+		return nil
+	}
+	return err
+}
+
+// settleDonation, bekleyen bağışı ödendi ya da başarısız durumuna bir kez geçirir.
+// Tekrarlanan bildirimler bağış artık "pending" olmadığı için etkisiz kalır.
+func settleDonation(ctx context.Context, donationID primitive.ObjectID, result payments.CallbackResult) error {
+	var donation models.Donation
+	if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+		return err
+	}
+	if donation.Status != models.DonationPending && donation.Status != models.DonationAuthorized {
+		return nil
+	}
+	if donation.ReviewReason != "" {
+		log.Printf("payment callback ignored for donation %s under review (%s)", donation.ID.Hex(), donation.ReviewReason)
+		return nil
+	}
+
+	transition := donationTransition{
+		To:     models.DonationFailed,
+		Reason: result.FailureReason,
+		Set:    bson.M{"paymentRef": result.Reference, "failureReason": result.FailureReason},
+	}
+	if result.Status == payments.CallbackSucceeded {
+		if result.Amount != donation.Amount {
+			// Sağlayıcı parayı tahsil etmiş olduğu için bağış başarısız sayılmaz; cüzdana yazılmadan
+			// incelemeye alınır ve yönetici iade ya da düzeltme yapar.
+			log.Printf("donation %s: %v (%s != %s), held for review", donation.ID.Hex(), errAmountMismatch, result.Amount, donation.Amount)
+			transition = donationTransition{
+				To:     models.DonationAuthorized,
+				Reason: errAmountMismatch.Error(),
+				Set: bson.M{
+					"paymentRef":     result.Reference,
+					"reviewReason":   models.ReviewAmountMismatch,
+					"capturedAmount": result.Amount,
+				},
+			}
+		} else {
+			transition = donationTransition{
+				To:     models.DonationPaid,
+				Reason: "payment " + string(result.Status),
+				Set:    bson.M{"paymentRef": result.Reference},
+			}
+		}
+	}
+
+	_, _, err := transitionDonation(ctx, donationID, transition)
+	if errors.Is(err, models.ErrInvalidTransition) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,125 @@
+package routes
+
+import (
+	"html/template"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"donation-app/server/payments"
+)
+
+var mockCheckoutPage = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html lang="tr">
+<head>
+<meta charset="utf-8">
+<title>Test ödemesi</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #f1f5f9; display: flex; justify-content: center; padding-top: 80px; }
+main { background: #fff; border-radius: 24px; padding: 32px; width: 360px; box-shadow: 0 10px 30px rgba(15, 23, 42, .08); }
+h1 { font-size: 18px; margin: 0 0 4px; }
+p { color: #64748b; font-size: 14px; }
+strong { display: block; font-size: 32px; margin: 16px 0; color: #0f172a; }
+button { width: 100%; border: 0; border-radius: 16px; padding: 12px; margin-top: 8px; font-weight: 600; cursor: pointer; }
+.succeed { background: #0f172a; color: #fff; }
+.fail { background: #fee2e2; color: #b91c1c; }
+.timeout { background: #e2e8f0; color: #334155; }
+</style>
+</head>
+<body>
+<main>
+<h1>{{.Description}}</h1>
+<p>Bu sayfa yerel geliştirme için sahte ödeme sağlayıcısıdır; gerçek para çekilmez.</p>
+<strong>{{.Amount}}</strong>
+<form method="post">
+<button class="succeed" name="outcome" value="succeed">Ödemeyi onayla</button>
+<button class="fail" name="outcome" value="fail">Ödemeyi reddet</button>
+<button class="timeout" name="outcome" value="timeout">Zaman aşımı</button>
+</form>
+</main>
+</body>
+</html>`))
+
+func registerMockPaymentRoutes(router fiber.Router) {
+	router.Get("/mock/checkout/:session", mockCheckoutPageHandler)
+	router.Post("/mock/checkout/:session", mockCheckoutActionHandler)
+}
+
+func activeMockProvider() (*payments.Mock, bool) {
+	mock, ok := payments.Active().(*payments.Mock)
+	return mock, ok
+}
+
+func mockCheckoutPageHandler(c *fiber.Ctx) error {
+	mock, ok := activeMockProvider()
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	session, ok := mock.Session(c.Params("session"))
+	if !ok {
+		return c.Status(fiber.StatusNotFound).SendString("Ödeme oturumu bulunamadı")
+	}
+
+	var page strings.Builder
+	if err := mockCheckoutPage.Execute(&page, fiber.Map{
+		"Description": session.Description,
+		"Amount":      session.Amount.String(),
+	}); err != nil {
+		return err
+	}
+
+	c.Type("html", "utf-8")
+	return c.SendString(page.String())
+}
+
+func mockCheckoutActionHandler(c *fiber.Ctx) error {
+	mock, ok := activeMockProvider()
+	if !ok {
+		return fiber.ErrNotFound
+	}
+
+	sessionID := c.Params("session")
+	session, ok := mock.Session(sessionID)
+	if !ok {
+		return c.Status(fiber.StatusNotFound).SendString("Ödeme oturumu bulunamadı")
+	}
+
+	outcome := payments.MockOutcome(c.FormValue("outcome"))
+	switch outcome {
+	case payments.MockSucceed, payments.MockFail:
+	case payments.MockTimeout:
+		// Zaman aşımında sağlayıcı bildirim göndermez; bağış "pending" kalır.
+		return c.Redirect(session.FailURL, fiber.StatusSeeOther)
+	default:
+		return c.Status(fiber.StatusBadRequest).SendString("Geçersiz seçim")
+	}
+
+	form, err := mock.CallbackForm(sessionID, outcome)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).SendString("Ödeme oturumu bulunamadı")
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.PostForm(mock.CallbackURL(), form)
+	if err != nil {
+		log.Printf("mock callback error: %v", err)
+		return c.Status(fiber.StatusBadGateway).SendString("Bildirim gönderilemedi, tekrar deneyin")
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	resp.Body.Close()
+	if err != nil || resp.StatusCode != http.StatusOK || strings.TrimSpace(string(body)) != mock.CallbackAck() {
+		log.Printf("mock callback not acknowledged: status %d", resp.StatusCode)
+		return c.Status(fiber.StatusBadGateway).SendString("Bildirim onaylanmadı, tekrar deneyin")
+	}
+	mock.CompleteSession(sessionID)
+
+	if outcome == payments.MockSucceed {
+		return c.Redirect(session.SuccessURL, fiber.StatusSeeOther)
+	}
+	return c.Redirect(session.FailURL, fiber.StatusSeeOther)
+}
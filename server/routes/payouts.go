@@ -0,0 +1,359 @@
+package routes
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/ledger"
+	"donation-app/server/middleware"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const defaultPayoutMinimum = "100.00"
+
+var errInsufficientFunds = errors.New("insufficient wallet balance")
+
+type payoutAccountRequest struct {
+	IBAN          string `json:"iban"`
+	AccountHolder string `json:"accountHolder"`
+}
+
+type createPayoutRequest struct {
+	Amount json.Number `json:"amount"`
+}
+
+type processPayoutRequest struct {
+	Reference string `json:"reference"`
+	Reason    string `json:"reason"`
+}
+
+func RegisterPayoutRoutes(router fiber.Router) {
+	protected := router.Group("", middleware.Protected())
+	protected.Get("/", listPayoutsHandler)
+	protected.Put("/account", updatePayoutAccountHandler)
+	protected.Post("/", middleware.Idempotency(), createPayoutHandler)
+	protected.Get("/statement", payoutStatementHandler)
+}
+
+func payoutMinimum(currency string) (models.Money, error) {
+	value := strings.TrimSpace(os.Getenv("PAYOUT_MIN_AMOUNT"))
+	if value == "" {
+		value = defaultPayoutMinimum
+	}
+	return models.ParseMoney(value, currency)
+}
+
+func updatePayoutAccountHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	var req payoutAccountRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+
+	iban := utils.NormalizeIBAN(req.IBAN)
+	if err := utils.ValidateTurkishIBAN(iban); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz IBAN")
+	}
+
+	holder := strings.TrimSpace(req.AccountHolder)
+	if holder == "" {
+		return utils.Error(c, fiber.StatusBadRequest, "Hesap sahibi adı gerekli")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.Collection("users").UpdateByID(ctx, user.ID, bson.M{"$set": bson.M{
+		"payoutIban":   iban,
+		"payoutHolder": holder,
+	}}); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hesap bilgisi kaydedilemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{
+		"iban":          utils.MaskIBAN(iban),
+		"accountHolder": holder,
+	})
+}
+
+func createPayoutHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	var req createPayoutRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+	}
+
+	if !user.EmailVerified {
+		return utils.Error(c, fiber.StatusForbidden, "Para çekmek için önce e-posta adresini doğrulamalısın")
+	}
+
+	if user.PayoutIBAN == "" {
+		return utils.Error(c, fiber.StatusBadRequest, "Önce bir IBAN kaydetmelisin")
+	}
+
+	amount, err := models.ParseMoney(req.Amount.String(), user.Wallet.Currency)
+	if err != nil || !amount.IsPositive() {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz tutar")
+	}
+
+	minimum, err := payoutMinimum(amount.Currency)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Ödeme limiti yapılandırılmamış")
+	}
+	if amount.Minor < minimum.Minor {
+		return utils.Error(c, fiber.StatusBadRequest, fmt.Sprintf("En az %s çekebilirsin", minimum))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	payout := models.Payout{
+		ID:            primitive.NewObjectID(),
+		UserID:        user.ID,
+		Amount:        amount,
+		IBAN:          user.PayoutIBAN,
+		AccountHolder: user.PayoutHolder,
+		Status:        models.PayoutRequested,
+		RequestedAt:   time.Now().UTC(),
+	}
+
+	err = database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		update, err := database.Collection("users").UpdateOne(sessCtx,
+			bson.M{"_id": user.ID, "wallet.currency": amount.Currency, "wallet.minor": bson.M{"$gte": amount.Minor}},
+			bson.M{
+				"$inc": bson.M{"wallet.minor": -amount.Minor, "heldBalance.minor": amount.Minor},
+				"$set": bson.M{"heldBalance.currency": amount.Currency},
+			},
+		)
+		if err != nil {
+			return err
+		}
+		if update.ModifiedCount == 0 {
+			return errInsufficientFunds
+		}
+
+		if _, err := database.Collection("payouts").InsertOne(sessCtx, payout); err != nil {
+			return err
+		}
+
+		_, err = ledger.Move(sessCtx, ledger.KindPayout, ledger.WalletAccount(user.ID), ledger.PayoutHoldAccount(user.ID), amount, payout.ID.Hex(), "payout requested")
+		return err
+	})
+	if errors.Is(err, errInsufficientFunds) {
+		return utils.Error(c, fiber.StatusBadRequest, "Cüzdan bakiyesi yetersiz")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Para çekme talebi oluşturulamadı")
+	}
+
+	return utils.Success(c, fiber.StatusCreated, fiber.Map{"payout": maskedPayout(payout)})
+}
+
+func listPayoutsHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	payouts, err := findPayouts(ctx, bson.M{"userId": user.ID}, 50)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Para çekme talepleri yüklenemedi")
+	}
+
+	var freshUser models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"_id": user.ID}).Decode(&freshUser); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Para çekme talepleri yüklenemedi")
+	}
+
+	minimum, _ := payoutMinimum(freshUser.Wallet.Currency)
+	for i := range payouts {
+		payouts[i] = maskedPayout(payouts[i])
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{
+		"account": fiber.Map{
+			"iban":          utils.MaskIBAN(freshUser.PayoutIBAN),
+			"accountHolder": freshUser.PayoutHolder,
+		},
+		"wallet":      freshUser.Wallet,
+		"heldBalance": freshUser.HeldBalance,
+		"minimum":     minimum,
+		"payouts":     payouts,
+	})
+}
+
+func payoutStatementHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	payouts, err := findPayouts(ctx, bson.M{"userId": user.ID, "status": models.PayoutCompleted}, 0)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Ekstre oluşturulamadı")
+	}
+
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	_ = writer.Write([]string{"payout_id", "requested_at", "completed_at", "amount", "currency", "iban", "account_holder", "reference"})
+	for _, payout := range payouts {
+		completedAt := ""
+		if payout.ProcessedAt != nil {
+			completedAt = payout.ProcessedAt.Format(time.RFC3339)
+		}
+		_ = writer.Write([]string{
+			payout.ID.Hex(),
+			payout.RequestedAt.Format(time.RFC3339),
+			completedAt,
+			payout.Amount.Decimal(),
+			payout.Amount.Currency,
+			utils.MaskIBAN(payout.IBAN),
+			payout.AccountHolder,
+			payout.Reference,
+		})
+	}
+	writer.Flush()
+
+	c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="payouts-%s.csv"`, user.Username))
+	return c.SendString(builder.String())
+}
+
+func adminListPayoutsHandler(c *fiber.Ctx) error {
+	status := models.PayoutStatus(c.Query("status", string(models.PayoutRequested)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	payouts, err := findPayouts(ctx, bson.M{"status": status}, 100)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Para çekme talepleri yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"payouts": payouts})
+}
+
+func adminApprovePayoutHandler(c *fiber.Ctx) error {
+	return processPayout(c, models.PayoutCompleted)
+}
+
+func adminRejectPayoutHandler(c *fiber.Ctx) error {
+	return processPayout(c, models.PayoutRejected)
+}
+
+func processPayout(c *fiber.Ctx, status models.PayoutStatus) error {
+	admin, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	payoutID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz talep kimliği")
+	}
+
+	var req processPayoutRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var payout models.Payout
+	err = database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		now := time.Now().UTC()
+		set := bson.M{"status": status, "processedAt": now, "processedBy": admin.ID}
+		if status == models.PayoutCompleted {
+			set["reference"] = strings.TrimSpace(req.Reference)
+		} else {
+			set["rejectReason"] = strings.TrimSpace(req.Reason)
+		}
+
+		payout = models.Payout{}
+		err := database.Collection("payouts").FindOneAndUpdate(sessCtx,
+			bson.M{"_id": payoutID, "status": models.PayoutRequested},
+			bson.M{"$set": set},
+			options.FindOneAndUpdate().SetReturnDocument(options.After),
+		).Decode(&payout)
+		if err != nil {
+			return err
+		}
+
+		userUpdate := bson.M{"heldBalance.minor": -payout.Amount.Minor}
+		from, to, memo := ledger.PayoutHoldAccount(payout.UserID), ledger.AccountClearing, "payout completed"
+		if status == models.PayoutRejected {
+			userUpdate["wallet.minor"] = payout.Amount.Minor
+			to, memo = ledger.WalletAccount(payout.UserID), "payout rejected"
+		}
+
+		if _, err := database.Collection("users").UpdateByID(sessCtx, payout.UserID, bson.M{"$inc": userUpdate}); err != nil {
+			return err
+		}
+
+		_, err = ledger.Move(sessCtx, ledger.KindPayout, from, to, payout.Amount, payout.ID.Hex(), memo)
+		return err
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return utils.Error(c, fiber.StatusConflict, "Talep bulunamadı ya da zaten işlenmiş")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Talep işlenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"payout": payout})
+}
+
+func findPayouts(ctx context.Context, filter bson.M, limit int64) ([]models.Payout, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "requestedAt", Value: -1}})
+	if limit > 0 {
+		opts.SetLimit(limit)
+	}
+
+	cursor, err := database.Collection("payouts").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	payouts := make([]models.Payout, 0)
+	if err := cursor.All(ctx, &payouts); err != nil {
+		return nil, err
+	}
+	return payouts, nil
+}
+
+func maskedPayout(payout models.Payout) models.Payout {
+	payout.IBAN = utils.MaskIBAN(payout.IBAN)
+	return payout
+}
@@ -0,0 +1,192 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/receipts"
+	"donation-app/server/utils"
+)
+
+var (
+	errReceiptNotIssuable = errors.New("donation is not eligible for a receipt")
+	errReceiptIssued      = errors.New("receipt issued concurrently")
+)
+
+// donationReceiptHandler, bağışın PDF makbuzunu bağışçıya, alıcıya ya da yöneticiye indirir.
+func donationReceiptHandler(c *fiber.Ctx) error {
+	viewer, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	donationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz bağış kimliği")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var donation models.Donation
+	if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+		return utils.Error(c, fiber.StatusNotFound, "Bağış bulunamadı")
+	}
+
+	isDonor := !donation.FromUserID.IsZero() && donation.FromUserID == viewer.ID
+	isRecipient := donation.ToUserID == viewer.ID
+	if !isDonor && !isRecipient && !viewer.IsAdmin() {
+		return utils.Error(c, fiber.StatusNotFound, "Bağış bulunamadı")
+	}
+
+	receipt, err := issueReceipt(ctx, donation.ID)
+	if errors.Is(err, errReceiptNotIssuable) {
+		return utils.Error(c, fiber.StatusConflict, "Makbuz yalnızca ödenmiş bağışlar için düzenlenir")
+	}
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Makbuz oluşturulamadı")
+	}
+	donation.Receipt = &receipt
+
+	data, err := buildReceipt(ctx, donation, isDonor || viewer.IsAdmin())
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Makbuz oluşturulamadı")
+	}
+
+	var buf bytes.Buffer
+	if err := receipts.Render(&buf, data); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Makbuz oluşturulamadı")
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="makbuz-%s.pdf"`, receipt.Number))
+	return c.Send(buf.Bytes())
+}
+
+// issueReceipt, bağışa makbuz numarası yoksa ödeme yılının sayacından sıradaki numarayı verir.
+// Numara bağışla aynı işlemde yazıldığı için eşzamanlı indirmeler numara atlatmaz.
+func issueReceipt(ctx context.Context, donationID primitive.ObjectID) (models.DonationReceipt, error) {
+	var receipt models.DonationReceipt
+	err := database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		var donation models.Donation
+		if err := database.Collection("donations").FindOne(sessCtx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+			return err
+		}
+		if donation.Receipt != nil {
+			receipt = *donation.Receipt
+			return nil
+		}
+		if donation.Status != models.DonationPaid || donation.PaidAt == nil {
+			return errReceiptNotIssuable
+		}
+
+		year := donation.PaidAt.In(receipts.Turkey).Year()
+		var counter struct {
+			Seq int64 `bson:"seq"`
+		}
+		err := database.Collection("counters").FindOneAndUpdate(sessCtx,
+			bson.M{"_id": fmt.Sprintf("receipt:%d", year)},
+			bson.M{"$inc": bson.M{"seq": 1}},
+			options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+		).Decode(&counter)
+		if err != nil {
+			return err
+		}
+
+		receipt = models.DonationReceipt{
+			Number:   receipts.FormatNumber(year, counter.Seq),
+			Year:     year,
+			Sequence: counter.Seq,
+			IssuedAt: time.Now().UTC(),
+		}
+		update, err := database.Collection("donations").UpdateOne(sessCtx,
+			bson.M{"_id": donationID, "receipt": bson.M{"$exists": false}},
+			bson.M{"$set": bson.M{"receipt": receipt}},
+		)
+		if err != nil {
+			return err
+		}
+		if update.ModifiedCount == 0 {
+			return errReceiptIssued
+		}
+		return nil
+	})
+	if errors.Is(err, errReceiptIssued) {
+		// Eşzamanlı indirmeyi kazanan istek numarayı yazdı; aynı numara döndürülür.
+		var donation models.Donation
+		if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+			return receipt, err
+		}
+		if donation.Receipt == nil {
+			return receipt, errReceiptIssued
+		}
+		return *donation.Receipt, nil
+	}
+	return receipt, err
+}
+
+// buildReceipt, makbuz içeriğini hazırlar. Bağışçının e-postası yalnızca bağışçıya ve yöneticiye,
+// anonim bağışçının kimliği yalnızca kendisine gösterilir.
+func buildReceipt(ctx context.Context, donation models.Donation, showDonor bool) (receipts.Receipt, error) {
+	var recipient models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"_id": donation.ToUserID}).Decode(&recipient); err != nil {
+		return receipts.Receipt{}, err
+	}
+
+	donor := receipts.Party{Name: donation.DonorName, Email: donation.DonorEmail}
+	if !donation.FromUserID.IsZero() {
+		var donorUser models.User
+		err := database.Collection("users").FindOne(ctx, bson.M{"_id": donation.FromUserID}).Decode(&donorUser)
+		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+			return receipts.Receipt{}, err
+		}
+		if err == nil {
+			donor.Username = donorUser.Username
+			if donor.Name == "" {
+				donor.Name = donorUser.Name
+			}
+			if donor.Email == "" {
+				donor.Email = donorUser.Email
+			}
+		}
+	}
+	if !showDonor {
+		donor.Email = ""
+		if donation.Anonymous {
+			donor = receipts.Party{Name: anonymousDonorName}
+		}
+	}
+
+	paidAt := donation.Date
+	if donation.PaidAt != nil {
+		paidAt = *donation.PaidAt
+	}
+
+	return receipts.Receipt{
+		Number:       donation.Receipt.Number,
+		IssuedAt:     donation.Receipt.IssuedAt,
+		Platform:     receipts.PlatformFromEnv(),
+		Donor:        donor,
+		Recipient:    receipts.Party{Name: recipient.Name, Username: recipient.Username},
+		DonationID:   donation.ID.Hex(),
+		PaymentRef:   donation.PaymentRef,
+		Provider:     donation.Provider,
+		PaidAt:       paidAt,
+		Status:       donation.Status,
+		Gross:        donation.Amount,
+		PlatformFee:  donation.PlatformFee,
+		ProcessorFee: donation.ProcessorFee,
+		Net:          donation.Net,
+	}, nil
+}
@@ -0,0 +1,148 @@
+package routes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"donation-app/server/database"
+	"donation-app/server/ledger"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+type walletAudit struct {
+	UserID     string       `json:"userId"`
+	Username   string       `json:"username"`
+	Account    string       `json:"account"`
+	Wallet     models.Money `json:"wallet"`
+	Ledger     models.Money `json:"ledger"`
+	Difference models.Money `json:"difference"`
+	Consistent bool         `json:"consistent"`
+	CheckedAt  time.Time    `json:"checkedAt"`
+}
+
+type reconcileResult struct {
+	walletAudit
+	Adjusted bool `json:"adjusted"`
+}
+
+func walletAuditHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	audit, err := auditWallet(ctx, user.ID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan denetlenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, audit)
+}
+
+func adminWalletAuditHandler(c *fiber.Ctx) error {
+	username := strings.ToLower(c.Params("username"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"username": username}).Decode(&user); err != nil {
+		return utils.Error(c, fiber.StatusNotFound, "Kullanıcı bulunamadı")
+	}
+
+	audit, err := auditWallet(ctx, user.ID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan denetlenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, audit)
+}
+
+// adminReconcileWalletHandler, cüzdan bakiyesine yazdığı için yalnızca yöneticilere açıktır;
+// kullanıcılar /wallet/audit ile yalnızca okuyabilir.
+func adminReconcileWalletHandler(c *fiber.Ctx) error {
+	username := strings.ToLower(c.Params("username"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var user models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"username": username}).Decode(&user); err != nil {
+		return utils.Error(c, fiber.StatusNotFound, "Kullanıcı bulunamadı")
+	}
+
+	result, err := reconcileWallet(ctx, user.ID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Cüzdan mutabakatı yapılamadı")
+	}
+
+	return utils.Success(c, fiber.StatusOK, result)
+}
+
+func auditWallet(ctx context.Context, userID primitive.ObjectID) (walletAudit, error) {
+	var user models.User
+	if err := database.Collection("users").FindOne(ctx, bson.M{"_id": userID}).Decode(&user); err != nil {
+		return walletAudit{}, err
+	}
+
+	account := ledger.WalletAccount(user.ID)
+	balance, err := ledger.Balance(ctx, account, user.Wallet.Currency)
+	if err != nil {
+		return walletAudit{}, err
+	}
+
+	difference, err := user.Wallet.Sub(balance)
+	if err != nil {
+		return walletAudit{}, err
+	}
+
+	return walletAudit{
+		UserID:     user.ID.Hex(),
+		Username:   user.Username,
+		Account:    string(account),
+		Wallet:     user.Wallet,
+		Ledger:     balance,
+		Difference: difference,
+		Consistent: difference.IsZero(),
+		CheckedAt:  time.Now().UTC(),
+	}, nil
+}
+
+// reconcileWallet, önbellekteki cüzdan bakiyesini ledger toplamıyla karşılaştırır ve
+// fark varsa bakiyeyi aynı işlem içinde ledger'a eşitler. Doğruluk kaynağı ledger'dır.
+func reconcileWallet(ctx context.Context, userID primitive.ObjectID) (reconcileResult, error) {
+	var result reconcileResult
+
+	err := database.WithTransaction(ctx, func(sessCtx mongo.SessionContext) error {
+		audit, err := auditWallet(sessCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		result = reconcileResult{walletAudit: audit}
+		if audit.Consistent {
+			return nil
+		}
+
+		if _, err := database.Collection("users").UpdateByID(sessCtx, userID, bson.M{"$set": bson.M{"wallet": audit.Ledger}}); err != nil {
+			return err
+		}
+		result.Wallet = audit.Ledger
+		result.Difference = models.Zero(audit.Ledger.Currency)
+		result.Consistent = true
+		result.Adjusted = true
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,217 @@
+package routes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/payments"
+	"donation-app/server/utils"
+)
+
+type reversalRequest struct {
+	Reason string `json:"reason"`
+}
+
+func refundDonationHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	donationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz bağış kimliği")
+	}
+
+	var req reversalRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var donation models.Donation
+	if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+		return utils.Error(c, fiber.StatusNotFound, "Bağış bulunamadı")
+	}
+
+	if donation.ToUserID != user.ID && !user.IsAdmin() {
+		return utils.Error(c, fiber.StatusForbidden, "Bu bağışı iade etme yetkin yok")
+	}
+	if donation.Status == models.DonationRefunded {
+		return utils.Success(c, fiber.StatusOK, fiber.Map{"donation": donationForViewer(donation, user)})
+	}
+	if !donation.Status.CanTransitionTo(models.DonationRefunded) {
+		return utils.Error(c, fiber.StatusConflict, "Yalnızca ödenmiş bağışlar iade edilebilir")
+	}
+
+	provider, ok := payments.Get(donation.Provider)
+	if !ok {
+		return utils.Error(c, fiber.StatusConflict, "Bu bağışın ödeme sağlayıcısı aktif değil")
+	}
+
+	claimed, err := claimRefund(ctx, donation.ID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "İade başlatılamadı")
+	}
+	if !claimed {
+		var current models.Donation
+		if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&current); err == nil && current.Status == models.DonationRefunded {
+			return utils.Success(c, fiber.StatusOK, fiber.Map{"donation": donationForViewer(current, user)})
+		}
+		return utils.Error(c, fiber.StatusConflict, "Bu bağış için iade zaten işleniyor")
+	}
+
+	if err := provider.Refund(ctx, payments.RefundRequest{
+		OrderID:   donation.ID.Hex(),
+		Amount:    donation.Amount,
+		Reference: donation.PaymentRef,
+	}); err != nil {
+		log.Printf("refund error (%s): %v", donation.ID.Hex(), err)
+		releaseRefundClaim(donation.ID)
+		return utils.Error(c, fiber.StatusBadGateway, "İade ödeme sağlayıcısında başarısız oldu")
+	}
+
+	reason := fmt.Sprintf("refund by %s", user.Username)
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		reason += ": " + trimmed
+	}
+
+	updated, _, err := transitionDonation(ctx, donation.ID, donationTransition{To: models.DonationRefunded, Reason: reason})
+	if err != nil {
+		// Sağlayıcı parayı iade etti ama kayıt güncellenemedi; talep bırakılmaz ki ikinci bir iade
+		// sağlayıcıya gitmesin, kayıt mutabakatla düzeltilir.
+		log.Printf("refund recorded at provider but not locally (%s): %v", donation.ID.Hex(), err)
+		return utils.Error(c, fiber.StatusInternalServerError, "İade kaydedilemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"donation": donationForViewer(updated, user)})
+}
+
+// claimRefund, ödenmiş bağışı sağlayıcıya gitmeden önce iade için koşullu olarak ayırır;
+// aynı bağış için eşzamanlı ikinci istek talebi alamaz ve sağlayıcıya iki kez iade gönderilmez.
+func claimRefund(ctx context.Context, donationID primitive.ObjectID) (bool, error) {
+	update, err := database.Collection("donations").UpdateOne(ctx,
+		bson.M{"_id": donationID, "status": models.DonationPaid, "refundClaimedAt": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"refundClaimedAt": time.Now().UTC()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return update.ModifiedCount == 1, nil
+}
+
+// releaseRefundClaim, sağlayıcı iadeyi reddettiğinde talebi kaldırır; istek zaman aşımına
+// uğramış olabileceği için ayrı bir bağlam kullanılır.
+func releaseRefundClaim(donationID primitive.ObjectID) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := database.Collection("donations").UpdateOne(ctx,
+		bson.M{"_id": donationID, "status": models.DonationPaid},
+		bson.M{"$unset": bson.M{"refundClaimedAt": ""}},
+	); err != nil {
+		log.Printf("refund claim release error (%s): %v", donationID.Hex(), err)
+	}
+}
+
+func chargebackDonationHandler(c *fiber.Ctx) error {
+	admin, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	donationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz bağış kimliği")
+	}
+
+	var req reversalRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçersiz istek")
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	reason := fmt.Sprintf("chargeback recorded by %s", admin.Username)
+	if trimmed := strings.TrimSpace(req.Reason); trimmed != "" {
+		reason += ": " + trimmed
+	}
+
+	updated, _, err := transitionDonation(ctx, donationID, donationTransition{To: models.DonationChargedBack, Reason: reason})
+	if err != nil {
+		switch {
+		case errors.Is(err, models.ErrInvalidTransition):
+			return utils.Error(c, fiber.StatusConflict, "Yalnızca ödenmiş bağışlar için ters ibraz kaydedilebilir")
+		case errors.Is(err, mongo.ErrNoDocuments):
+			return utils.Error(c, fiber.StatusNotFound, "Bağış bulunamadı")
+		}
+		return utils.Error(c, fiber.StatusInternalServerError, "Ters ibraz kaydedilemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"donation": updated})
+}
+
+func donationForViewer(donation models.Donation, viewer models.User) models.Donation {
+	if viewer.IsAdmin() {
+		return donation
+	}
+	return donation.ForRecipient()
+}
+
+func adminDonationHandler(c *fiber.Ctx) error {
+	donationID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz bağış kimliği")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var donation models.Donation
+	if err := database.Collection("donations").FindOne(ctx, bson.M{"_id": donationID}).Decode(&donation); err != nil {
+		return utils.Error(c, fiber.StatusNotFound, "Bağış bulunamadı")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"donation": donation})
+}
+
+// adminReviewDonationsHandler, tahsil edilmiş ama cüzdana yazılmamış, inceleme bekleyen bağışları listeler.
+func adminReviewDonationsHandler(c *fiber.Ctx) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := database.Collection("donations").Find(ctx,
+		bson.M{"reviewReason": bson.M{"$exists": true}, "status": models.DonationAuthorized},
+		options.Find().SetSort(bson.D{{Key: "statusChangedAt", Value: 1}}).SetLimit(100),
+	)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağışlar yüklenemedi")
+	}
+	defer cursor.Close(ctx)
+
+	donations := make([]models.Donation, 0)
+	if err := cursor.All(ctx, &donations); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Bağışlar yüklenemedi")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"donations": donations})
+}
@@ -0,0 +1,250 @@
+package routes
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"donation-app/server/database"
+	"donation-app/server/models"
+	"donation-app/server/utils"
+)
+
+const maxUserAgentLength = 512
+
+type sessionView struct {
+	models.Session
+	Current bool `json:"current"`
+}
+
+// startSession, giriş ve kayıt sonrası sunucu tarafı oturumu açar ve ilk belirteç çiftini verir.
+func startSession(ctx context.Context, c *fiber.Ctx, userID primitive.ObjectID) (tokenPair, error) {
+	userAgent := c.Get(fiber.HeaderUserAgent)
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+
+	now := time.Now().UTC()
+	session := models.Session{
+		ID:         primitive.NewObjectID(),
+		UserID:     userID,
+		Device:     describeDevice(userAgent),
+		IP:         c.IP(),
+		UserAgent:  userAgent,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(refreshTokenTTL),
+	}
+	if _, err := database.Collection("sessions").InsertOne(ctx, session); err != nil {
+		return tokenPair{}, err
+	}
+
+	tokens, _, err := issueTokens(ctx, userID, session.ID)
+	return tokens, err
+}
+
+func logoutHandler(c *fiber.Ctx) error {
+	session, ok := c.Locals("session").(models.Session)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := revokeSessions(ctx, []primitive.ObjectID{session.ID}, "logout"); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Çıkış yapılamadı")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Çıkış yapıldı"})
+}
+
+func listSessionsHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+	current, _ := c.Locals("session").(models.Session)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := database.Collection("sessions").Find(ctx,
+		bson.M{"userId": user.ID, "revokedAt": bson.M{"$exists": false}, "expiresAt": bson.M{"$gt": time.Now().UTC()}},
+		options.Find().SetSort(bson.D{{Key: "lastSeenAt", Value: -1}}),
+	)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Oturumlar yüklenemedi")
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []models.Session
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Oturumlar yüklenemedi")
+	}
+
+	views := make([]sessionView, 0, len(sessions))
+	for _, session := range sessions {
+		views = append(views, sessionView{Session: session, Current: session.ID == current.ID})
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"sessions": views})
+}
+
+func revokeSessionHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+
+	sessionID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return utils.Error(c, fiber.StatusBadRequest, "Geçersiz oturum kimliği")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	count, err := database.Collection("sessions").CountDocuments(ctx, bson.M{"_id": sessionID, "userId": user.ID, "revokedAt": bson.M{"$exists": false}})
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Oturum sonlandırılamadı")
+	}
+	if count == 0 {
+		return utils.Error(c, fiber.StatusNotFound, "Oturum bulunamadı")
+	}
+
+	if err := revokeSessions(ctx, []primitive.ObjectID{sessionID}, "revoked"); err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Oturum sonlandırılamadı")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Oturum sonlandırıldı"})
+}
+
+// revokeOtherSessionsHandler, isteği yapan oturum dışındaki bütün oturumları kapatır.
+func revokeOtherSessionsHandler(c *fiber.Ctx) error {
+	user, ok := c.Locals("user").(models.User)
+	if !ok {
+		return utils.Error(c, fiber.StatusUnauthorized, "Oturum geçerli değil")
+	}
+	current, _ := c.Locals("session").(models.Session)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	revoked, err := revokeUserSessions(ctx, user.ID, current.ID, "revoked")
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Oturumlar sonlandırılamadı")
+	}
+
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"message": "Diğer oturumlar sonlandırıldı", "revoked": revoked})
+}
+
+// revokeUserSessions, kullanıcının except dışındaki tüm açık oturumlarını iptal eder.
+// except sıfır değerse hepsi kapatılır.
+func revokeUserSessions(ctx context.Context, userID, except primitive.ObjectID, reason string) (int, error) {
+	filter := bson.M{"userId": userID, "revokedAt": bson.M{"$exists": false}}
+	if !except.IsZero() {
+		filter["_id"] = bson.M{"$ne": except}
+	}
+
+	cursor, err := database.Collection("sessions").Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID primitive.ObjectID `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return 0, err
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(docs))
+	for _, doc := range docs {
+		ids = append(ids, doc.ID)
+	}
+	return len(ids), revokeSessions(ctx, ids, reason)
+}
+
+// revokeSessions, oturumları ve onlara ait yenileme belirteci ailelerini birlikte iptal eder;
+// Protected oturumu kontrol ettiği için mevcut erişim belirteçleri de hemen geçersizleşir.
+func revokeSessions(ctx context.Context, sessionIDs []primitive.ObjectID, reason string) error {
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	now := time.Now().UTC()
+	set := bson.M{"$set": bson.M{"revokedAt": now, "revokeReason": reason}}
+
+	if _, err := database.Collection("sessions").UpdateMany(ctx,
+		bson.M{"_id": bson.M{"$in": sessionIDs}, "revokedAt": bson.M{"$exists": false}}, set,
+	); err != nil {
+		return err
+	}
+	_, err := database.Collection("refresh_tokens").UpdateMany(ctx,
+		bson.M{"familyId": bson.M{"$in": sessionIDs}, "revokedAt": bson.M{"$exists": false}}, set,
+	)
+	return err
+}
+
+// touchSession, yenileme sırasında oturumun son görülme zamanını ve süresini uzatır.
+func touchSession(ctx context.Context, sessionID primitive.ObjectID, ip string) error {
+	now := time.Now().UTC()
+	result, err := database.Collection("sessions").UpdateOne(ctx,
+		bson.M{"_id": sessionID, "revokedAt": bson.M{"$exists": false}, "expiresAt": bson.M{"$gt": now}},
+		bson.M{"$set": bson.M{"lastSeenAt": now, "ip": ip, "expiresAt": now.Add(refreshTokenTTL)}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return errRefreshInvalid
+	}
+	return nil
+}
+
+// describeDevice, kullanıcı aracısından oturum listesinde gösterilecek kısa bir ad çıkarır.
+func describeDevice(userAgent string) string {
+	ua := strings.ToLower(userAgent)
+
+	browser := "Bilinmeyen tarayıcı"
+	switch {
+	case strings.Contains(ua, "edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "opr/") || strings.Contains(ua, "opera"):
+		browser = "Opera"
+	case strings.Contains(ua, "firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "safari/"):
+		browser = "Safari"
+	case strings.Contains(ua, "obs"):
+		browser = "OBS"
+	}
+
+	platform := ""
+	switch {
+	case strings.Contains(ua, "android"):
+		platform = "Android"
+	case strings.Contains(ua, "iphone") || strings.Contains(ua, "ipad"):
+		platform = "iOS"
+	case strings.Contains(ua, "windows"):
+		platform = "Windows"
+	case strings.Contains(ua, "mac os"):
+		platform = "macOS"
+	case strings.Contains(ua, "linux"):
+		platform = "Linux"
+	}
+
+	if platform == "" {
+		return browser
+	}
+	return browser + " · " + platform
+}
@@ -1,3 +0,0 @@
-package routes
-
-// Legacy Stripe dosyası boş bırakıldı. Yeni ödeme rotaları `iyzico.go` içinde yer alıyor.
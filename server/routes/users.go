@@ -2,6 +2,8 @@ package routes
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
@@ -15,12 +17,15 @@ import (
 )
 
 type updateProfileRequest struct {
-	Bio        *string `json:"bio"`
-	ProfilePic *string `json:"profilePic"`
+	Bio              *string      `json:"bio"`
+	ProfilePic       *string      `json:"profilePic"`
+	MessageMaxLength *int         `json:"messageMaxLength"`
+	MessageMinAmount *json.Number `json:"messageMinAmount"`
+	HoldMessages     *bool        `json:"holdMessages"`
 }
 
 func RegisterUserRoutes(router fiber.Router) {
-	router.Get("/:username", getProfile)
+	router.Get("/:username", middleware.OptionalAuth(), getProfile)
 
 	protected := router.Group("", middleware.Protected())
 	protected.Put("/update", updateProfile)
@@ -35,8 +40,20 @@ func getProfile(c *fiber.Ctx) error {
 	if err := database.Collection("users").FindOne(ctx, bson.M{"username": username}).Decode(&user); err != nil {
 		return utils.Error(c, fiber.StatusNotFound, "Kullanıcı bulunamadı")
 	}
+	// Doğrulanmamış hesaplar herkese açık listelenmez; sahibi ve yöneticiler profili görebilir.
+	if !user.EmailVerified {
+		viewer, ok := c.Locals("user").(models.User)
+		if !ok || (viewer.ID != user.ID && !viewer.IsAdmin()) {
+			return utils.Error(c, fiber.StatusNotFound, "Kullanıcı bulunamadı")
+		}
+	}
+
+	goals, err := activeGoalsProgress(ctx, user.ID)
+	if err != nil {
+		return utils.Error(c, fiber.StatusInternalServerError, "Hedefler yüklenemedi")
+	}
 
-	return utils.Success(c, fiber.StatusOK, fiber.Map{"user": user.PublicProfile()})
+	return utils.Success(c, fiber.StatusOK, fiber.Map{"user": user.PublicProfile(), "goals": goals})
 }
 
 func updateProfile(c *fiber.Ctx) error {
@@ -60,6 +77,22 @@ func updateProfile(c *fiber.Ctx) error {
 	if req.ProfilePic != nil {
 		update["profilePic"] = *req.ProfilePic
 	}
+	if req.MessageMaxLength != nil {
+		if *req.MessageMaxLength < 1 || *req.MessageMaxLength > models.MaxMessageLength {
+			return utils.Error(c, fiber.StatusBadRequest, fmt.Sprintf("Mesaj uzunluğu 1 ile %d arasında olmalı", models.MaxMessageLength))
+		}
+		update["messageSettings.maxLength"] = *req.MessageMaxLength
+	}
+	if req.MessageMinAmount != nil {
+		minAmount, err := models.ParseMoney(req.MessageMinAmount.String(), user.Wallet.Currency)
+		if err != nil {
+			return utils.Error(c, fiber.StatusBadRequest, "Geçersiz minimum mesaj tutarı")
+		}
+		update["messageSettings.minAmount"] = minAmount
+	}
+	if req.HoldMessages != nil {
+		update["messageSettings.holdMessages"] = *req.HoldMessages
+	}
 
 	if len(update) == 0 {
 		return utils.Error(c, fiber.StatusBadRequest, "Güncellenecek alan bulunamadı")
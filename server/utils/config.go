@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"os"
+	"strings"
+)
+
+func FrontendURL() string {
+	frontendURL := strings.TrimSpace(os.Getenv("FRONTEND_URL"))
+	if frontendURL == "" {
+		frontendURL = "http://localhost:5173"
+	}
+	return strings.TrimRight(frontendURL, "/")
+}
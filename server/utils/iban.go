@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+const turkishIBANLength = 26
+
+var ErrInvalidIBAN = errors.New("invalid IBAN")
+
+func NormalizeIBAN(iban string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(iban), ""))
+}
+
+// ValidateTurkishIBAN, TR + 2 kontrol + 5 banka kodu + 1 rezerv (0) + 16 hesap numarası
+// biçimini ve ISO 13616 mod-97 kontrolünü doğrular.
+func ValidateTurkishIBAN(iban string) error {
+	iban = NormalizeIBAN(iban)
+	if len(iban) != turkishIBANLength || !strings.HasPrefix(iban, "TR") {
+		return ErrInvalidIBAN
+	}
+
+	for _, r := range iban[2:] {
+		if r < '0' || r > '9' {
+			return ErrInvalidIBAN
+		}
+	}
+	if iban[9] != '0' {
+		return ErrInvalidIBAN
+	}
+
+	rearranged := iban[4:] + iban[:4]
+	remainder := 0
+	for _, r := range rearranged {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A') + 10) % 97
+		default:
+			return ErrInvalidIBAN
+		}
+	}
+
+	if remainder != 1 {
+		return ErrInvalidIBAN
+	}
+	return nil
+}
+
+func MaskIBAN(iban string) string {
+	iban = NormalizeIBAN(iban)
+	if len(iban) <= 8 {
+		return iban
+	}
+	return iban[:4] + strings.Repeat("*", len(iban)-8) + iban[len(iban)-4:]
+}
@@ -8,7 +8,8 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 )
 
-const tokenExpirationHours = 24 * 7
+// Erişim belirteçleri kısa ömürlüdür; oturum yenileme belirteciyle sürdürülür.
+const AccessTokenTTL = 15 * time.Minute
 
 func getSecret() (string, error) {
 	secret := os.Getenv("JWT_SECRET")
@@ -18,15 +19,19 @@ func getSecret() (string, error) {
 	return secret, nil
 }
 
-func GenerateToken(userID string) (string, error) {
+// GenerateAccessToken, kullanıcı ve yenileme ailesi (sid) kimliğini taşıyan erişim belirteci üretir.
+func GenerateAccessToken(userID string, sessionID string) (string, error) {
 	secret, err := getSecret()
 	if err != nil {
 		return "", err
 	}
 
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"sub": userID,
-		"exp": time.Now().Add(tokenExpirationHours * time.Hour).Unix(),
+		"sid": sessionID,
+		"iat": now.Unix(),
+		"exp": now.Add(AccessTokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -46,3 +51,52 @@ func ValidateToken(tokenString string) (*jwt.Token, error) {
 		return []byte(secret), nil
 	})
 }
+
+const (
+	EmailVerificationTTL     = 48 * time.Hour
+	emailVerificationPurpose = "email_verification"
+)
+
+var ErrInvalidVerificationToken = errors.New("invalid email verification token")
+
+// GenerateEmailVerificationToken, doğrulama bağlantısına konan imzalı belirteci üretir. Adres
+// belirtecin içinde taşındığı için e-posta değişirse eski bağlantılar geçersiz kalır.
+func GenerateEmailVerificationToken(userID string, email string) (string, error) {
+	secret, err := getSecret()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub":   userID,
+		"email": email,
+		"pur":   emailVerificationPurpose,
+		"iat":   now.Unix(),
+		"exp":   now.Add(EmailVerificationTTL).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(secret))
+}
+
+// ParseEmailVerificationToken, belirtecin imzasını, süresini ve amacını doğrulayıp kullanıcı
+// kimliğiyle e-posta adresini döndürür.
+func ParseEmailVerificationToken(tokenString string) (string, string, error) {
+	token, err := ValidateToken(tokenString)
+	if err != nil || !token.Valid {
+		return "", "", ErrInvalidVerificationToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", "", ErrInvalidVerificationToken
+	}
+	purpose, _ := claims["pur"].(string)
+	userID, _ := claims["sub"].(string)
+	email, _ := claims["email"].(string)
+	if purpose != emailVerificationPurpose || userID == "" || email == "" {
+		return "", "", ErrInvalidVerificationToken
+	}
+	return userID, email, nil
+}